@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/auth"
+)
+
+// AuthService exposes the login/refresh endpoints that mint the bearer
+// tokens the weather routes require once AUTH_ENABLED is set. It only
+// works against the HMAC key source: a JWKS-configured deployment expects
+// an external identity provider to mint tokens instead, so issuer is nil
+// in that case and both handlers respond 503.
+//
+// Neither handler authenticates an end user - both require the caller to
+// present loginAPIKey, a shared secret held by the trusted upstream
+// gateway that performs real end-user authentication and is trusted to
+// say who the subject is and which tier they're on. Without a matching
+// key, login is refused outright.
+type AuthService struct {
+	issuer      *auth.Issuer
+	loginAPIKey string
+}
+
+// NewAuthService returns an AuthService minting tokens via issuer, which
+// may be nil if the active KeySource isn't HMAC-backed. loginAPIKey is
+// required on every login/refresh call; if empty, login is disabled.
+func NewAuthService(issuer *auth.Issuer, loginAPIKey string) *AuthService {
+	return &AuthService{issuer: issuer, loginAPIKey: loginAPIKey}
+}
+
+// authorizedCaller reports whether r presents the configured login API
+// key via the X-Login-Api-Key header. Login is refused entirely when no
+// key is configured.
+func (as *AuthService) authorizedCaller(r *http.Request) bool {
+	if as.loginAPIKey == "" {
+		return false
+	}
+	presented := r.Header.Get("X-Login-Api-Key")
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(as.loginAPIKey)) == 1
+}
+
+type loginRequest struct {
+	Subject string `json:"subject"`
+	Tier    string `json:"tier"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Login handles POST /auth/login, issuing a fresh access/refresh token
+// pair for the given subject. The caller must present the configured
+// login API key (see AuthService) - this endpoint mints tokens on behalf
+// of a trusted gateway, it does not itself verify end-user credentials.
+func (as *AuthService) Login(w http.ResponseWriter, r *http.Request) {
+	if as.issuer == nil {
+		http.Error(w, "token issuance is not configured for this deployment", http.StatusServiceUnavailable)
+		return
+	}
+	if !as.authorizedCaller(r) {
+		http.Error(w, "missing or invalid X-Login-Api-Key", http.StatusUnauthorized)
+		return
+	}
+
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Subject == "" {
+		http.Error(w, "request body must include a non-empty subject", http.StatusBadRequest)
+		return
+	}
+	if req.Tier != auth.TierPremium {
+		req.Tier = auth.TierFree
+	}
+
+	pair, err := as.issuer.IssueTokenPair(req.Subject, req.Tier)
+	if err != nil {
+		http.Error(w, "failed to issue tokens", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, pair)
+}
+
+// Refresh handles POST /auth/refresh, exchanging a refresh token for a
+// new access token. Like Login, it requires the shared login API key.
+func (as *AuthService) Refresh(w http.ResponseWriter, r *http.Request) {
+	if as.issuer == nil {
+		http.Error(w, "token issuance is not configured for this deployment", http.StatusServiceUnavailable)
+		return
+	}
+	if !as.authorizedCaller(r) {
+		http.Error(w, "missing or invalid X-Login-Api-Key", http.StatusUnauthorized)
+		return
+	}
+
+	var req refreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		http.Error(w, "request body must include refresh_token", http.StatusBadRequest)
+		return
+	}
+
+	pair, err := as.issuer.RefreshAccessToken(req.RefreshToken)
+	if err != nil {
+		http.Error(w, "invalid or expired refresh token", http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, pair)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/cache"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/metar"
+)
+
+const (
+	// metarCacheTTL matches a METAR's typical hourly validity window.
+	metarCacheTTL = time.Hour
+	// tafCacheTTL matches how often TAFs are routinely reissued.
+	tafCacheTTL       = 6 * time.Hour
+	aviationCacheSize = 500
+)
+
+// AviationService serves the METAR/TAF routes, parsing raw reports fetched
+// from a single configured metar.Source and caching them for the report's
+// validity window.
+type AviationService struct {
+	source     metar.Source
+	metarCache *cache.TTLLRU
+	tafCache   *cache.TTLLRU
+
+	requestCounter *prometheus.CounterVec
+	responseTime   *prometheus.HistogramVec
+}
+
+// NewAviationService returns an AviationService fetching reports from source.
+func NewAviationService(source metar.Source) *AviationService {
+	return &AviationService{
+		source:     source,
+		metarCache: cache.New(aviationCacheSize, metarCacheTTL),
+		tafCache:   cache.New(aviationCacheSize, tafCacheTTL),
+		requestCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "aviation_api_requests_total",
+			Help: "Total number of aviation weather API requests, labeled by report type.",
+		}, []string{"report_type"}),
+		responseTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "aviation_api_response_time_seconds",
+			Help: "Response time for aviation weather API requests, labeled by report type.",
+		}, []string{"report_type"}),
+	}
+}
+
+// Collectors returns the Prometheus collectors the caller should register.
+func (as *AviationService) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{as.requestCounter, as.responseTime}
+}
+
+// GetMETAR handles GET /metar/{station}.
+func (as *AviationService) GetMETAR(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	as.requestCounter.WithLabelValues("metar").Inc()
+
+	station := mux.Vars(r)["station"]
+
+	if cached, ok := as.metarCache.Get(station); ok {
+		writeJSON(w, cached)
+		as.responseTime.WithLabelValues("metar").Observe(time.Since(start).Seconds())
+		return
+	}
+
+	raw, err := as.source.FetchMETAR(r.Context(), station)
+	if err != nil {
+		log.Printf("metar fetch error: %v", err)
+		http.Error(w, "failed to fetch METAR report", http.StatusBadGateway)
+		return
+	}
+
+	obs, err := metar.ParseRawMETAR(raw)
+	if err != nil {
+		log.Printf("metar parse error: %v", err)
+		http.Error(w, fmt.Sprintf("failed to parse METAR report: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	as.metarCache.Set(station, obs)
+	writeJSON(w, obs)
+	as.responseTime.WithLabelValues("metar").Observe(time.Since(start).Seconds())
+}
+
+// GetTAF handles GET /taf/{station}.
+func (as *AviationService) GetTAF(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	as.requestCounter.WithLabelValues("taf").Inc()
+
+	station := mux.Vars(r)["station"]
+
+	if cached, ok := as.tafCache.Get(station); ok {
+		writeJSON(w, cached)
+		as.responseTime.WithLabelValues("taf").Observe(time.Since(start).Seconds())
+		return
+	}
+
+	raw, err := as.source.FetchTAF(r.Context(), station)
+	if err != nil {
+		log.Printf("taf fetch error: %v", err)
+		http.Error(w, "failed to fetch TAF report", http.StatusBadGateway)
+		return
+	}
+
+	taf, err := metar.ParseRawTAF(raw)
+	if err != nil {
+		log.Printf("taf parse error: %v", err)
+		http.Error(w, fmt.Sprintf("failed to parse TAF report: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	as.tafCache.Set(station, taf)
+	writeJSON(w, taf)
+	as.responseTime.WithLabelValues("taf").Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/influx"
+)
+
+// defaultHistoryResolution is used when the ?resolution= query parameter
+// is omitted or invalid.
+const defaultHistoryResolution = time.Hour
+
+// HistoryService serves GET /history/{location}, backed by InfluxDB data
+// the background collector wrote.
+type HistoryService struct {
+	influx *influx.Client
+}
+
+// NewHistoryService returns a HistoryService querying client.
+func NewHistoryService(client *influx.Client) *HistoryService {
+	return &HistoryService{influx: client}
+}
+
+// GetHistory handles GET /history/{location}?from=...&to=...&resolution=1h.
+// from/to are RFC3339 timestamps; to defaults to now and from defaults to
+// 24h before to.
+func (hs *HistoryService) GetHistory(w http.ResponseWriter, r *http.Request) {
+	if hs.influx == nil {
+		http.Error(w, "history is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	location := mux.Vars(r)["location"]
+	query := r.URL.Query()
+
+	to := parseTimeDefault(query.Get("to"), time.Now())
+	from := parseTimeDefault(query.Get("from"), to.Add(-24*time.Hour))
+
+	resolution := defaultHistoryResolution
+	if raw := query.Get("resolution"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			resolution = d
+		}
+	}
+
+	points, err := hs.influx.QueryHistory(location, from, to, resolution)
+	if err != nil {
+		log.Printf("history query error: %v", err)
+		http.Error(w, "failed to query history", http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, points)
+}
+
+func parseTimeDefault(s string, def time.Time) time.Time {
+	if s == "" {
+		return def
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return def
+	}
+	return t
+}
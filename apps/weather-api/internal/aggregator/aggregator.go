@@ -0,0 +1,268 @@
+// Package aggregator fans a weather lookup out to multiple providers in
+// parallel and reconciles their results into a single, outlier-resistant
+// WeatherData response.
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/providers"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// errAllProvidersFailed is returned by Aggregate when every configured
+// provider failed to return a result.
+var errAllProvidersFailed = errors.New("aggregator: all providers failed")
+
+const (
+	// DefaultProviderTimeout bounds how long a single provider is given to
+	// respond before it's treated as failed for this request.
+	DefaultProviderTimeout = 3 * time.Second
+	// DefaultOutlierStdDevs is the number of standard deviations from the
+	// median a provider's value may differ by before it's dropped.
+	DefaultOutlierStdDevs = 2.0
+)
+
+// SourceResult records one provider's contribution to an aggregated
+// response, regardless of whether it was ultimately included.
+type SourceResult struct {
+	Provider  string               `json:"provider"`
+	Value     *weather.WeatherData `json:"value,omitempty"`
+	LatencyMS int64                `json:"latency_ms"`
+	Included  bool                 `json:"included"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// Result is the reconciled, multi-source weather response.
+type Result struct {
+	weather.WeatherData
+	Sources    []SourceResult `json:"sources"`
+	Confidence float64        `json:"confidence"`
+}
+
+// Aggregator fans a lookup out to a fixed set of providers and reconciles
+// their results.
+type Aggregator struct {
+	providers      []providers.Provider
+	timeout        time.Duration
+	outlierStdDevs float64
+
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+// New returns an Aggregator querying provs in parallel, each bounded by
+// timeout, dropping values more than outlierStdDevs standard deviations
+// from the per-field median.
+func New(provs []providers.Provider, timeout time.Duration, outlierStdDevs float64) *Aggregator {
+	return &Aggregator{
+		providers:      provs,
+		timeout:        timeout,
+		outlierStdDevs: outlierStdDevs,
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_provider_requests_total",
+			Help: "Total number of upstream provider requests, labeled by provider and outcome.",
+		}, []string{"provider", "outcome"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "weather_provider_request_duration_seconds",
+			Help: "Upstream provider request latency, labeled by provider.",
+		}, []string{"provider"}),
+	}
+}
+
+// Collectors returns the Prometheus collectors the caller should register.
+func (a *Aggregator) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{a.requestsTotal, a.requestDuration}
+}
+
+type fieldSample struct {
+	provider string
+	value    float64
+}
+
+// Aggregate queries every configured provider for loc in parallel and
+// reconciles the results. It returns an error only when every provider
+// fails.
+func (a *Aggregator) Aggregate(ctx context.Context, loc weather.Location) (Result, error) {
+	raw := make([]SourceResult, len(a.providers))
+	data := make([]weather.WeatherData, len(a.providers))
+	ok := make([]bool, len(a.providers))
+
+	var wg sync.WaitGroup
+	for i, p := range a.providers {
+		wg.Add(1)
+		go func(i int, p providers.Provider) {
+			defer wg.Done()
+
+			pctx, cancel := context.WithTimeout(ctx, a.timeout)
+			defer cancel()
+
+			start := time.Now()
+			d, err := p.GetWeather(pctx, loc)
+			latency := time.Since(start)
+			a.requestDuration.WithLabelValues(p.Name()).Observe(latency.Seconds())
+
+			if err != nil {
+				a.requestsTotal.WithLabelValues(p.Name(), "error").Inc()
+				raw[i] = SourceResult{Provider: p.Name(), LatencyMS: latency.Milliseconds(), Error: err.Error()}
+				return
+			}
+
+			a.requestsTotal.WithLabelValues(p.Name(), "success").Inc()
+			data[i] = d
+			ok[i] = true
+			raw[i] = SourceResult{Provider: p.Name(), Value: &d, LatencyMS: latency.Milliseconds()}
+		}(i, p)
+	}
+	wg.Wait()
+
+	temps := fieldSamples(raw, data, ok, func(d weather.WeatherData) float64 { return d.Temperature })
+	humidity := fieldSamples(raw, data, ok, func(d weather.WeatherData) float64 { return float64(d.Humidity) })
+	pressure := fieldSamples(raw, data, ok, func(d weather.WeatherData) float64 { return d.Pressure })
+	wind := fieldSamples(raw, data, ok, func(d weather.WeatherData) float64 { return d.WindSpeed })
+
+	tempIncluded := outlierMask(temps, a.outlierStdDevs)
+	humidityIncluded := outlierMask(humidity, a.outlierStdDevs)
+	pressureIncluded := outlierMask(pressure, a.outlierStdDevs)
+	windIncluded := outlierMask(wind, a.outlierStdDevs)
+
+	included := 0
+	result := Result{}
+	for i := range raw {
+		if !ok[i] {
+			continue
+		}
+		if tempIncluded[i] && humidityIncluded[i] && pressureIncluded[i] && windIncluded[i] {
+			raw[i].Included = true
+			included++
+		}
+	}
+	result.Sources = raw
+
+	result.Temperature = mean(includedValues(temps, tempIncluded))
+	result.Humidity = int(mean(includedValues(humidity, humidityIncluded)))
+	result.Pressure = mean(includedValues(pressure, pressureIncluded))
+	result.WindSpeed = mean(includedValues(wind, windIncluded))
+	result.Location = loc.Raw
+	result.Timestamp = time.Now()
+	result.Source = "aggregated"
+
+	total := 0
+	for _, v := range ok {
+		if v {
+			total++
+		}
+	}
+	if total == 0 {
+		return Result{}, errAllProvidersFailed
+	}
+	result.Confidence = float64(included) / float64(total)
+
+	return result, nil
+}
+
+// fieldSamples extracts one numeric field from every successfully-fetched
+// provider's data, preserving index alignment with raw/data/ok.
+func fieldSamples(raw []SourceResult, data []weather.WeatherData, ok []bool, field func(weather.WeatherData) float64) []fieldSample {
+	samples := make([]fieldSample, len(raw))
+	for i := range raw {
+		if !ok[i] {
+			continue
+		}
+		samples[i] = fieldSample{provider: raw[i].Provider, value: field(data[i])}
+	}
+	return samples
+}
+
+// outlierMask returns, for each index with a present sample, whether that
+// sample falls within stdDevs standard deviations of the median. Indexes
+// without a sample (failed providers) are false.
+func outlierMask(samples []fieldSample, stdDevs float64) []bool {
+	var values []float64
+	present := make([]bool, len(samples))
+	for i, s := range samples {
+		if s.provider == "" {
+			continue
+		}
+		values = append(values, s.value)
+		present[i] = true
+	}
+
+	mask := make([]bool, len(samples))
+	if len(values) == 0 {
+		return mask
+	}
+
+	med := median(values)
+	sd := stdDev(values, med)
+
+	for i, s := range samples {
+		if !present[i] {
+			continue
+		}
+		if sd == 0 || absFloat(s.value-med) <= stdDevs*sd {
+			mask[i] = true
+		}
+	}
+	return mask
+}
+
+func includedValues(samples []fieldSample, mask []bool) []float64 {
+	var values []float64
+	for i, s := range samples {
+		if mask[i] {
+			values = append(values, s.value)
+		}
+	}
+	return values
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+func stdDev(values []float64, mean float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sumSq float64
+	for _, v := range values {
+		d := v - mean
+		sumSq += d * d
+	}
+	return math.Sqrt(sumSq / float64(len(values)))
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func absFloat(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,63 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/providers"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+type fakeProvider struct {
+	name string
+	data weather.WeatherData
+	err  error
+}
+
+func (f *fakeProvider) Name() string { return f.name }
+
+func (f *fakeProvider) GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error) {
+	if f.err != nil {
+		return weather.WeatherData{}, f.err
+	}
+	return f.data, nil
+}
+
+func TestAggregateDropsOutlier(t *testing.T) {
+	agg := New([]providers.Provider{
+		&fakeProvider{name: "a", data: weather.WeatherData{Temperature: 20, Humidity: 60, Pressure: 1013, WindSpeed: 5}},
+		&fakeProvider{name: "b", data: weather.WeatherData{Temperature: 21, Humidity: 61, Pressure: 1012, WindSpeed: 5.2}},
+		&fakeProvider{name: "c", data: weather.WeatherData{Temperature: 90, Humidity: 62, Pressure: 1014, WindSpeed: 5.1}},
+	}, time.Second, 1.0)
+
+	result, err := agg.Aggregate(context.Background(), weather.Location{Raw: "testtown"})
+	if err != nil {
+		t.Fatalf("Aggregate() error = %v", err)
+	}
+
+	if result.Confidence >= 1.0 {
+		t.Errorf("Confidence = %v, want < 1.0 since provider c is an outlier", result.Confidence)
+	}
+
+	for _, src := range result.Sources {
+		if src.Provider == "c" && src.Included {
+			t.Error("provider c should have been excluded as a temperature outlier")
+		}
+	}
+
+	if result.Temperature > 25 {
+		t.Errorf("Temperature = %v, want close to 20/21 (outlier excluded)", result.Temperature)
+	}
+}
+
+func TestAggregateAllProvidersFail(t *testing.T) {
+	agg := New([]providers.Provider{
+		&fakeProvider{name: "a", err: errors.New("boom")},
+	}, time.Second, DefaultOutlierStdDevs)
+
+	if _, err := agg.Aggregate(context.Background(), weather.Location{Raw: "testtown"}); err == nil {
+		t.Error("Aggregate() error = nil, want error when every provider fails")
+	}
+}
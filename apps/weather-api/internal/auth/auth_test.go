@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIssuerRoundTrip(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"))
+
+	pair, err := issuer.IssueTokenPair("user-1", TierPremium)
+	if err != nil {
+		t.Fatalf("IssueTokenPair() error = %v", err)
+	}
+
+	mw := NewMiddleware(Config{Enabled: true, KeySource: NewHMACKeySource([]byte("test-secret"))})
+
+	var gotTier string
+	handler := mw.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromRequest(r)
+		if !ok {
+			t.Fatal("expected claims in request context")
+		}
+		gotTier = claims.Tier
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/london", nil)
+	req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if gotTier != TierPremium {
+		t.Errorf("claims.Tier = %q, want %q", gotTier, TierPremium)
+	}
+}
+
+func TestMiddlewareRejectsMissingOrInvalidToken(t *testing.T) {
+	mw := NewMiddleware(Config{Enabled: true, KeySource: NewHMACKeySource([]byte("test-secret"))})
+	handler := mw.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run for an unauthenticated request")
+	}))
+
+	for name, setup := range map[string]func(*http.Request){
+		"no header":     func(r *http.Request) {},
+		"not bearer":    func(r *http.Request) { r.Header.Set("Authorization", "Basic abc123") },
+		"garbage token": func(r *http.Request) { r.Header.Set("Authorization", "Bearer not-a-jwt") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/weather/london", nil)
+			setup(req)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", rec.Code)
+			}
+		})
+	}
+}
+
+func TestMiddlewarePassesThroughWhenDisabled(t *testing.T) {
+	mw := NewMiddleware(Config{Enabled: false})
+	ran := false
+	handler := mw.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ran = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/london", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !ran || rec.Code != http.StatusOK {
+		t.Errorf("request with no token should pass through when auth is disabled")
+	}
+}
+
+func TestMiddlewareRejectsRefreshToken(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"))
+	pair, err := issuer.IssueTokenPair("user-1", TierFree)
+	if err != nil {
+		t.Fatalf("IssueTokenPair() error = %v", err)
+	}
+
+	mw := NewMiddleware(Config{Enabled: true, KeySource: NewHMACKeySource([]byte("test-secret"))})
+	handler := mw.RequireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run for a refresh token")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/london", nil)
+	req.Header.Set("Authorization", "Bearer "+pair.RefreshToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401 for a refresh token used as a bearer token", rec.Code)
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	issuer := NewIssuer([]byte("test-secret"))
+	pair, err := issuer.IssueTokenPair("user-1", TierFree)
+	if err != nil {
+		t.Fatalf("IssueTokenPair() error = %v", err)
+	}
+
+	refreshed, err := issuer.RefreshAccessToken(pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("RefreshAccessToken() error = %v", err)
+	}
+	if refreshed.AccessToken == "" {
+		t.Error("expected a new access token")
+	}
+
+	if _, err := issuer.RefreshAccessToken(pair.AccessToken); err == nil {
+		t.Error("RefreshAccessToken() with an access token = nil error, want error")
+	}
+}
+
+func TestClaimsTierDefaultsToFree(t *testing.T) {
+	c := &Claims{}
+	if got := c.tier(); got != TierFree {
+		t.Errorf("tier() = %q, want %q for empty claims", got, TierFree)
+	}
+
+	c.Tier = "bogus"
+	if got := c.tier(); got != TierFree {
+		t.Errorf("tier() = %q, want %q for an unrecognized tier", got, TierFree)
+	}
+
+	c.Tier = TierPremium
+	if got := c.tier(); got != TierPremium {
+		t.Errorf("tier() = %q, want %q", got, TierPremium)
+	}
+}
@@ -0,0 +1,64 @@
+// Package auth validates JWT bearer tokens (HMAC- or JWKS-signed) and
+// carries the resulting claims through request context.
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Tier values recognized by the per-subject rate limiter.
+const (
+	TierFree    = "free"
+	TierPremium = "premium"
+)
+
+// Claims is CloudWeather Sentinel's JWT claim schema:
+//
+//	{
+//	  "sub": "user-123",       // subject: rate-limited and logged per this value
+//	  "tier": "free|premium",  // which GCRA quota applies
+//	  "token_use": "access|refresh",
+//	  "exp": 1700000000,       // standard registered claims also apply
+//	  "iat": 1699999700
+//	}
+type Claims struct {
+	Tier     string `json:"tier"`
+	TokenUse string `json:"token_use"`
+	jwt.RegisteredClaims
+}
+
+type contextKey int
+
+const claimsContextKey contextKey = iota
+
+// WithClaims returns a context carrying claims, retrievable via
+// ClaimsFromContext.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// ClaimsFromContext returns the Claims stored by the JWT middleware, if
+// any. Requests to routes that don't require auth (or when auth is
+// disabled) will not have claims.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
+
+// ClaimsFromRequest is a convenience wrapper around ClaimsFromContext.
+func ClaimsFromRequest(r *http.Request) (*Claims, bool) {
+	return ClaimsFromContext(r.Context())
+}
+
+// tier returns the claim's tier, defaulting to free for unrecognized or
+// missing values so a misconfigured token doesn't silently get premium
+// quota.
+func (c *Claims) tier() string {
+	if c.Tier == TierPremium {
+		return TierPremium
+	}
+	return TierFree
+}
@@ -0,0 +1,59 @@
+package auth
+
+import "time"
+
+const defaultJWKSRefresh = 10 * time.Minute
+
+// Config controls whether JWT authentication is enforced and which
+// KeySource validates bearer tokens.
+type Config struct {
+	Enabled    bool
+	KeySource  KeySource
+	HMACSecret []byte // set only when the HMAC key source is active; needed to mint tokens
+
+	// LoginAPIKey gates POST /auth/login and /auth/refresh. It's meant to
+	// be held by the trusted caller (e.g. an internal gateway or admin
+	// tool) that has already authenticated the end user and is now asking
+	// CloudWeather Sentinel to mint that user a token - it is NOT a
+	// substitute for real end-user credential verification. Login is
+	// refused entirely when this is unset, since an unguarded login
+	// endpoint would let anyone self-issue a premium-tier token.
+	LoginAPIKey string
+}
+
+// NewConfigFromEnv reads:
+//
+//	AUTH_ENABLED     - "true" to require a valid bearer token on weather
+//	                    routes (default: false)
+//	JWT_HMAC_SECRET  - shared secret for HS256 tokens; if set, takes
+//	                    precedence over JWT_JWKS_URL
+//	JWT_JWKS_URL     - JWKS endpoint for RS256 tokens, used when
+//	                    JWT_HMAC_SECRET is unset
+//	AUTH_LOGIN_API_KEY - shared secret the caller of /auth/login and
+//	                    /auth/refresh must present in the
+//	                    X-Login-Api-Key header. Login is disabled
+//	                    entirely when unset.
+//
+// When AUTH_ENABLED is true but neither JWT_HMAC_SECRET nor JWT_JWKS_URL
+// is set, auth is left disabled so a misconfiguration doesn't lock every
+// route behind an impossible check.
+func NewConfigFromEnv(getenv func(string) string) Config {
+	cfg := Config{
+		Enabled:     getenv("AUTH_ENABLED") == "true",
+		LoginAPIKey: getenv("AUTH_LOGIN_API_KEY"),
+	}
+
+	if secret := getenv("JWT_HMAC_SECRET"); secret != "" {
+		cfg.HMACSecret = []byte(secret)
+		cfg.KeySource = NewHMACKeySource(cfg.HMACSecret)
+		return cfg
+	}
+
+	if jwksURL := getenv("JWT_JWKS_URL"); jwksURL != "" {
+		cfg.KeySource = NewJWKSKeySource(jwksURL, defaultJWKSRefresh, nil)
+		return cfg
+	}
+
+	cfg.Enabled = false
+	return cfg
+}
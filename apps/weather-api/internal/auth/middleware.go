@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Middleware validates the Authorization header on every request it
+// wraps, rejecting with 401 unless Config.Enabled is false.
+type Middleware struct {
+	cfg Config
+}
+
+// NewMiddleware returns a Middleware enforcing cfg.
+func NewMiddleware(cfg Config) *Middleware {
+	return &Middleware{cfg: cfg}
+}
+
+// RequireAuth parses and validates a "Bearer <token>" Authorization
+// header, storing the resulting Claims in the request context. If auth
+// is disabled the request is passed through unchanged. Tokens with
+// token_use=refresh are rejected, since only /auth/refresh accepts those.
+func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.cfg.Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		raw := r.Header.Get("Authorization")
+		const prefix = "Bearer "
+		if !strings.HasPrefix(raw, prefix) {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		claims := &Claims{}
+		_, err := jwt.ParseWithClaims(strings.TrimPrefix(raw, prefix), claims, m.cfg.KeySource.Keyfunc)
+		if err != nil {
+			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+		if claims.TokenUse == TokenUseRefresh {
+			http.Error(w, "refresh tokens may not be used to authenticate requests", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithClaims(r.Context(), claims)))
+	})
+}
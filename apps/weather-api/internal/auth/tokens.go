@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// TokenUseAccess marks a short-lived token accepted by the weather
+	// routes.
+	TokenUseAccess = "access"
+	// TokenUseRefresh marks a long-lived token only accepted by
+	// /auth/refresh.
+	TokenUseRefresh = "refresh"
+
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
+// Issuer mints HMAC-signed access/refresh token pairs. Only configured
+// when the active KeySource is HMAC-based - a JWKS source implies tokens
+// are minted by an external identity provider instead.
+type Issuer struct {
+	secret []byte
+}
+
+// NewIssuer returns an Issuer signing tokens with secret.
+func NewIssuer(secret []byte) *Issuer {
+	return &Issuer{secret: secret}
+}
+
+// TokenPair is the response shape for POST /auth/login and /auth/refresh.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// IssueTokenPair mints a fresh access/refresh token pair for subject at tier.
+func (i *Issuer) IssueTokenPair(subject, tier string) (TokenPair, error) {
+	access, err := i.issue(subject, tier, TokenUseAccess, accessTokenTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refresh, err := i.issue(subject, tier, TokenUseRefresh, refreshTokenTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return TokenPair{AccessToken: access, RefreshToken: refresh, ExpiresIn: int(accessTokenTTL.Seconds())}, nil
+}
+
+// RefreshAccessToken validates a refresh token and mints a new access
+// token for the same subject/tier.
+func (i *Issuer) RefreshAccessToken(refreshToken string) (TokenPair, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(refreshToken, claims, NewHMACKeySource(i.secret).Keyfunc)
+	if err != nil {
+		return TokenPair{}, fmt.Errorf("auth: invalid refresh token: %w", err)
+	}
+	if claims.TokenUse != TokenUseRefresh {
+		return TokenPair{}, fmt.Errorf("auth: token is not a refresh token")
+	}
+
+	access, err := i.issue(claims.Subject, claims.tier(), TokenUseAccess, accessTokenTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	return TokenPair{AccessToken: access, RefreshToken: refreshToken, ExpiresIn: int(accessTokenTTL.Seconds())}, nil
+}
+
+func (i *Issuer) issue(subject, tier, tokenUse string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		Tier:     tier,
+		TokenUse: tokenUse,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(i.secret)
+}
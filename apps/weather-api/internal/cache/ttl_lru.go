@@ -0,0 +1,84 @@
+// Package cache provides a small in-memory LRU cache with per-entry TTLs,
+// used to avoid hammering upstream providers for repeat lookups.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// TTLLRU is a fixed-capacity, least-recently-used cache where entries also
+// expire after a configurable time-to-live. It is safe for concurrent use.
+type TTLLRU struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type entry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// New returns a TTLLRU holding at most capacity entries, each valid for ttl.
+func New(capacity int, ttl time.Duration) *TTLLRU {
+	return &TTLLRU{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached value for key, if present and not expired.
+func (c *TTLLRU) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.removeElement(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores value under key, evicting the least-recently-used entry if the
+// cache is at capacity.
+func (c *TTLLRU) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		e.value = value
+		e.expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&entry{
+		key:       key,
+		value:     value,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+	c.items[key] = el
+
+	if c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *TTLLRU) removeElement(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).key)
+}
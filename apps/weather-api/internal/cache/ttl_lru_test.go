@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLLRUGetSet(t *testing.T) {
+	c := New(2, time.Minute)
+
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = (%v, %v), want (1, true)", v, ok)
+	}
+
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get(missing) = ok, want miss")
+	}
+}
+
+func TestTTLLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // a is now most-recently-used
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) = ok, want evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("Get(a) = miss, want hit")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("Get(c) = miss, want hit")
+	}
+}
+
+func TestTTLLRUExpires(t *testing.T) {
+	c := New(2, time.Millisecond)
+	c.Set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) = ok, want expired miss")
+	}
+}
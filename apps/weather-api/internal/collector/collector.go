@@ -0,0 +1,131 @@
+// Package collector periodically polls configured locations and persists
+// the results to InfluxDB for later historical queries.
+package collector
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/providers"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// DefaultInterval is how often the collector polls each configured
+// location when COLLECTOR_INTERVAL isn't set.
+const DefaultInterval = 10 * time.Minute
+
+// Writer persists a single WeatherData observation. influx.Client
+// satisfies this.
+type Writer interface {
+	WritePoint(location, source string, data weather.WeatherData) error
+}
+
+// Config controls which locations the collector polls and how often.
+type Config struct {
+	Interval  time.Duration
+	Locations []string
+}
+
+// NewConfigFromEnv reads COLLECTOR_INTERVAL (a Go duration string, e.g.
+// "10m") and COLLECTOR_LOCATIONS (a comma-separated list of location path
+// variables, e.g. "London,Denver").
+func NewConfigFromEnv(getenv func(string) string) Config {
+	cfg := Config{Interval: DefaultInterval}
+	if raw := getenv("COLLECTOR_INTERVAL"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil {
+			cfg.Interval = d
+		}
+	}
+	if raw := getenv("COLLECTOR_LOCATIONS"); raw != "" {
+		for _, loc := range strings.Split(raw, ",") {
+			if loc = strings.TrimSpace(loc); loc != "" {
+				cfg.Locations = append(cfg.Locations, loc)
+			}
+		}
+	}
+	return cfg
+}
+
+// Collector polls provider for each configured location on a fixed
+// interval and writes the results to writer, the same Provider
+// abstraction the live /weather endpoint uses.
+type Collector struct {
+	provider  providers.Provider
+	writer    Writer
+	interval  time.Duration
+	locations []weather.Location
+
+	pollsTotal  *prometheus.CounterVec
+	writeErrors prometheus.Counter
+}
+
+// New returns a Collector polling provider for cfg.Locations every
+// cfg.Interval and persisting results via writer.
+func New(provider providers.Provider, writer Writer, cfg Config) *Collector {
+	locations := make([]weather.Location, len(cfg.Locations))
+	for i, l := range cfg.Locations {
+		locations[i] = weather.Location{Raw: l, City: l}
+	}
+
+	return &Collector{
+		provider:  provider,
+		writer:    writer,
+		interval:  cfg.Interval,
+		locations: locations,
+		pollsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "weather_collector_polls_total",
+			Help: "Total number of background collector polls, labeled by outcome.",
+		}, []string{"outcome"}),
+		writeErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "weather_collector_influx_write_errors_total",
+			Help: "Total number of failed InfluxDB writes from the background collector.",
+		}),
+	}
+}
+
+// Collectors returns the Prometheus collectors the caller should register.
+func (c *Collector) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.pollsTotal, c.writeErrors}
+}
+
+// Run polls every configured location once per interval until ctx is
+// canceled. It degrades gracefully - a fetch or write failure for one
+// location is logged and counted, not fatal.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	c.pollAll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.pollAll(ctx)
+		}
+	}
+}
+
+func (c *Collector) pollAll(ctx context.Context) {
+	for _, loc := range c.locations {
+		data, err := c.provider.GetWeather(ctx, loc)
+		if err != nil {
+			log.Printf("collector: fetch %s from %s: %v", loc.Raw, c.provider.Name(), err)
+			c.pollsTotal.WithLabelValues("fetch_error").Inc()
+			continue
+		}
+
+		if err := c.writer.WritePoint(loc.Raw, c.provider.Name(), data); err != nil {
+			log.Printf("collector: influx unreachable, dropping point for %s: %v", loc.Raw, err)
+			c.pollsTotal.WithLabelValues("write_error").Inc()
+			c.writeErrors.Inc()
+			continue
+		}
+
+		c.pollsTotal.WithLabelValues("success").Inc()
+	}
+}
@@ -0,0 +1,69 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+type fakeProvider struct {
+	name string
+	err  error
+}
+
+func (f *fakeProvider) Name() string { return f.name }
+
+func (f *fakeProvider) GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error) {
+	if f.err != nil {
+		return weather.WeatherData{}, f.err
+	}
+	return weather.WeatherData{Location: loc.Raw, Temperature: 20}, nil
+}
+
+type fakeWriter struct {
+	writes []weather.WeatherData
+	err    error
+}
+
+func (f *fakeWriter) WritePoint(location, source string, data weather.WeatherData) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.writes = append(f.writes, data)
+	return nil
+}
+
+func TestCollectorPollAllWritesEachLocation(t *testing.T) {
+	provider := &fakeProvider{name: "mock"}
+	writer := &fakeWriter{}
+	c := New(provider, writer, Config{Interval: time.Second, Locations: []string{"London", "Denver"}})
+
+	c.pollAll(context.Background())
+
+	if len(writer.writes) != 2 {
+		t.Fatalf("len(writes) = %d, want 2", len(writer.writes))
+	}
+}
+
+func TestCollectorPollAllDegradesOnInfluxError(t *testing.T) {
+	provider := &fakeProvider{name: "mock"}
+	writer := &fakeWriter{err: errors.New("influx unreachable")}
+	c := New(provider, writer, Config{Interval: time.Second, Locations: []string{"London"}})
+
+	c.pollAll(context.Background()) // must not panic despite the write failure
+}
+
+func TestCollectorPollAllDegradesOnFetchError(t *testing.T) {
+	provider := &fakeProvider{name: "mock", err: errors.New("upstream down")}
+	writer := &fakeWriter{}
+	c := New(provider, writer, Config{Interval: time.Second, Locations: []string{"London"}})
+
+	c.pollAll(context.Background())
+
+	if len(writer.writes) != 0 {
+		t.Fatalf("len(writes) = %d, want 0 when the fetch fails", len(writer.writes))
+	}
+}
@@ -0,0 +1,60 @@
+// Package corsmw implements an allowlist-based CORS middleware, replacing
+// a wide-open Access-Control-Allow-Origin: * policy with one that only
+// reflects configured origins.
+package corsmw
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Config lists the origins permitted to make cross-origin requests.
+type Config struct {
+	AllowedOrigins []string
+}
+
+// NewConfigFromEnv reads CORS_ALLOWED_ORIGINS, a comma-separated list of
+// origins (e.g. "https://app.example.com,https://admin.example.com").
+// An unset or empty value allows no cross-origin requests.
+func NewConfigFromEnv(getenv func(string) string) Config {
+	var cfg Config
+	for _, origin := range strings.Split(getenv("CORS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			cfg.AllowedOrigins = append(cfg.AllowedOrigins, origin)
+		}
+	}
+	return cfg
+}
+
+func (c Config) allowed(origin string) bool {
+	for _, o := range c.AllowedOrigins {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware returns an http middleware that reflects the request's
+// Origin header back in Access-Control-Allow-Origin only when it appears
+// in cfg's allowlist, and answers preflight OPTIONS requests directly.
+func Middleware(cfg Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin != "" && cfg.allowed(origin) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Vary", "Origin")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-CSRF-Token")
+			}
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
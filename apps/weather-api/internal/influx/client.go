@@ -0,0 +1,101 @@
+// Package influx persists WeatherData points to InfluxDB and serves
+// downsampled historical queries back out.
+package influx
+
+import (
+	"fmt"
+	"time"
+
+	influxclient "github.com/influxdata/influxdb1-client/v2"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+const measurement = "weather"
+
+// Config holds the settings needed to talk to InfluxDB.
+type Config struct {
+	URL      string
+	Database string
+	Username string
+	Password string
+}
+
+// NewConfigFromEnv reads INFLUX_URL, INFLUX_DATABASE, INFLUX_USERNAME, and
+// INFLUX_PASSWORD.
+func NewConfigFromEnv(getenv func(string) string) Config {
+	cfg := Config{
+		URL:      getenv("INFLUX_URL"),
+		Database: getenv("INFLUX_DATABASE"),
+		Username: getenv("INFLUX_USERNAME"),
+		Password: getenv("INFLUX_PASSWORD"),
+	}
+	if cfg.URL == "" {
+		cfg.URL = "http://localhost:8086"
+	}
+	if cfg.Database == "" {
+		cfg.Database = "cloudweather"
+	}
+	return cfg
+}
+
+// Client writes WeatherData points to, and queries them back out of,
+// InfluxDB.
+type Client struct {
+	cfg    Config
+	influx influxclient.Client
+}
+
+// NewClient returns a Client using cfg.
+func NewClient(cfg Config) (*Client, error) {
+	c, err := influxclient.NewHTTPClient(influxclient.HTTPConfig{
+		Addr:     cfg.URL,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		Timeout:  5 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("influx: build client: %w", err)
+	}
+	return &Client{cfg: cfg, influx: c}, nil
+}
+
+// WritePoint writes a single WeatherData observation, tagged by location
+// and source.
+func (c *Client) WritePoint(location, source string, data weather.WeatherData) error {
+	bp, err := influxclient.NewBatchPoints(influxclient.BatchPointsConfig{
+		Database:  c.cfg.Database,
+		Precision: "s",
+	})
+	if err != nil {
+		return fmt.Errorf("influx: build batch: %w", err)
+	}
+
+	tags := map[string]string{"location": location, "source": source}
+	fields := map[string]interface{}{
+		"temperature": data.Temperature,
+		"humidity":    data.Humidity,
+		"pressure":    data.Pressure,
+		"wind_speed":  data.WindSpeed,
+	}
+
+	pt, err := influxclient.NewPoint(measurement, tags, fields, data.Timestamp)
+	if err != nil {
+		return fmt.Errorf("influx: build point: %w", err)
+	}
+	bp.AddPoint(pt)
+
+	if err := c.influx.Write(bp); err != nil {
+		return fmt.Errorf("influx: write: %w", err)
+	}
+	return nil
+}
+
+// Ping verifies InfluxDB is reachable.
+func (c *Client) Ping() error {
+	_, _, err := c.influx.Ping(5 * time.Second)
+	if err != nil {
+		return fmt.Errorf("influx: ping: %w", err)
+	}
+	return nil
+}
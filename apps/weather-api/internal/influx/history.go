@@ -0,0 +1,95 @@
+package influx
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	influxclient "github.com/influxdata/influxdb1-client/v2"
+)
+
+// HistoryPoint is one downsampled row of historical weather data.
+type HistoryPoint struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Temperature float64   `json:"temperature"`
+	Humidity    float64   `json:"humidity"`
+	Pressure    float64   `json:"pressure"`
+	WindSpeed   float64   `json:"wind_speed"`
+}
+
+// QueryHistory returns the mean of each numeric field for location, bucketed
+// by resolution, between from and to (inclusive). location is bound as a
+// query parameter rather than interpolated into the InfluxQL text, since it
+// comes straight from the request path with no validation.
+func (c *Client) QueryHistory(location string, from, to time.Time, resolution time.Duration) ([]HistoryPoint, error) {
+	command := fmt.Sprintf(
+		`SELECT mean("temperature") AS temperature, mean("humidity") AS humidity, mean("pressure") AS pressure, mean("wind_speed") AS wind_speed FROM %q WHERE "location" = $location AND time >= '%s' AND time <= '%s' GROUP BY time(%s) fill(none)`,
+		measurement,
+		from.UTC().Format(time.RFC3339),
+		to.UTC().Format(time.RFC3339),
+		resolution,
+	)
+
+	query := influxclient.NewQueryWithParameters(command, c.cfg.Database, "", map[string]interface{}{
+		"location": location,
+	})
+
+	resp, err := c.influx.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("influx: query: %w", err)
+	}
+	if resp.Error() != nil {
+		return nil, fmt.Errorf("influx: query result: %w", resp.Error())
+	}
+
+	return parseHistoryResponse(resp)
+}
+
+// parseHistoryResponse flattens the single series InfluxDB returns for a
+// GROUP BY time() query into HistoryPoints, matching the
+// time/temperature/humidity/pressure/wind_speed column order from
+// QueryHistory's SELECT clause.
+func parseHistoryResponse(resp *influxclient.Response) ([]HistoryPoint, error) {
+	var points []HistoryPoint
+	if len(resp.Results) == 0 || len(resp.Results[0].Series) == 0 {
+		return points, nil
+	}
+
+	series := resp.Results[0].Series[0]
+	colIndex := make(map[string]int, len(series.Columns))
+	for i, col := range series.Columns {
+		colIndex[col] = i
+	}
+
+	for _, row := range series.Values {
+		ts, err := time.Parse(time.RFC3339, row[colIndex["time"]].(string))
+		if err != nil {
+			return nil, fmt.Errorf("influx: parse row timestamp: %w", err)
+		}
+		points = append(points, HistoryPoint{
+			Timestamp:   ts,
+			Temperature: valueAsFloat(row[colIndex["temperature"]]),
+			Humidity:    valueAsFloat(row[colIndex["humidity"]]),
+			Pressure:    valueAsFloat(row[colIndex["pressure"]]),
+			WindSpeed:   valueAsFloat(row[colIndex["wind_speed"]]),
+		})
+	}
+	return points, nil
+}
+
+// valueAsFloat converts an InfluxDB JSON query result value (typically a
+// json.Number or nil for a gap) to a float64.
+func valueAsFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case nil:
+		return 0
+	case float64:
+		return n
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	default:
+		f, _ := strconv.ParseFloat(fmt.Sprint(n), 64)
+		return f
+	}
+}
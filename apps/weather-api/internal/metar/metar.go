@@ -0,0 +1,74 @@
+// Package metar parses raw METAR/TAF aviation weather reports, regardless
+// of which upstream (NOAA's ADDS text feed or an AVWX-style JSON API)
+// supplied the raw text.
+package metar
+
+import "time"
+
+// Flight rules categories, derived from ceiling and surface visibility per
+// the FAA's standard thresholds.
+const (
+	VFR  = "VFR"
+	MVFR = "MVFR"
+	IFR  = "IFR"
+	LIFR = "LIFR"
+)
+
+// Wind describes a METAR's surface wind group.
+type Wind struct {
+	DirectionDeg int  `json:"direction_deg"`
+	Variable     bool `json:"variable"`
+	SpeedKt      int  `json:"speed_kt"`
+	GustKt       int  `json:"gust_kt,omitempty"`
+}
+
+// CloudLayer is a single sky condition group, e.g. "BKN025".
+type CloudLayer struct {
+	Cover    string `json:"cover"` // FEW | SCT | BKN | OVC | VV
+	BaseFeet int    `json:"base_feet"`
+}
+
+// Observation is a parsed METAR current-conditions report.
+type Observation struct {
+	Station       string       `json:"station"`
+	ObservedAt    time.Time    `json:"observed_at"`
+	FlightRules   string       `json:"flight_rules"`
+	Wind          Wind         `json:"wind"`
+	VisibilitySM  float64      `json:"visibility_sm"`
+	Clouds        []CloudLayer `json:"clouds"`
+	WxCodes       []string     `json:"wx_codes,omitempty"`
+	TemperatureC  float64      `json:"temperature_c"`
+	DewpointC     float64      `json:"dewpoint_c"`
+	AltimeterInHg float64      `json:"altimeter_in_hg"`
+	RawText       string       `json:"raw_text"`
+}
+
+// ceilingFeet returns the base of the lowest broken/overcast/vertical
+// visibility layer, or -1 if the sky is clear or only scattered/few.
+func (o Observation) ceilingFeet() int {
+	ceiling := -1
+	for _, c := range o.Clouds {
+		if c.Cover != "BKN" && c.Cover != "OVC" && c.Cover != "VV" {
+			continue
+		}
+		if ceiling == -1 || c.BaseFeet < ceiling {
+			ceiling = c.BaseFeet
+		}
+	}
+	return ceiling
+}
+
+// classifyFlightRules derives VFR/MVFR/IFR/LIFR from ceiling and visibility
+// using the FAA's standard breakpoints.
+func classifyFlightRules(ceilingFeet int, visibilitySM float64) string {
+	switch {
+	case (ceilingFeet != -1 && ceilingFeet < 500) || visibilitySM < 1:
+		return LIFR
+	case (ceilingFeet != -1 && ceilingFeet < 1000) || visibilitySM < 3:
+		return IFR
+	case (ceilingFeet != -1 && ceilingFeet <= 3000) || visibilitySM <= 5:
+		return MVFR
+	default:
+		return VFR
+	}
+}
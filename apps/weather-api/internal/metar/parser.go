@@ -0,0 +1,147 @@
+package metar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeRe      = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	windRe      = regexp.MustCompile(`^(\d{3}|VRB)(\d{2,3})(G(\d{2,3}))?KT$`)
+	visSMRe     = regexp.MustCompile(`^(\d+)(?:/(\d+))?SM$`)
+	visMetersRe = regexp.MustCompile(`^(\d{4})$`)
+	cloudRe     = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|VV)(\d{3})$`)
+	tempDewpRe  = regexp.MustCompile(`^(M?\d{2})/(M?\d{2})$`)
+	altimeterRe = regexp.MustCompile(`^A(\d{4})$`)
+	qnhRe       = regexp.MustCompile(`^Q(\d{4})$`)
+	wxCodeRe    = regexp.MustCompile(`^[-+]?(VC)?(MI|PR|BC|DR|BL|SH|TS|FZ)?(DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)$`)
+)
+
+// ParseRawMETAR parses a single raw METAR report, as produced by either
+// NOAA's ADDS text feed or the "raw" field of an AVWX-style JSON response.
+//
+// This is a practical subset parser covering the groups CloudWeather
+// Sentinel surfaces (wind, visibility, clouds, weather phenomena,
+// temperature/dewpoint, altimeter) - it does not attempt runway visual
+// range, trend, or remarks-group parsing.
+func ParseRawMETAR(raw string) (Observation, error) {
+	raw = strings.TrimSpace(raw)
+	tokens := strings.Fields(raw)
+	if len(tokens) < 2 {
+		return Observation{}, fmt.Errorf("metar: report too short: %q", raw)
+	}
+
+	obs := Observation{Station: tokens[0], RawText: raw}
+
+	for _, tok := range tokens[1:] {
+		switch {
+		case timeRe.MatchString(tok):
+			obs.ObservedAt = parseObservationTime(tok)
+		case windRe.MatchString(tok):
+			obs.Wind = parseWind(tok)
+		case visSMRe.MatchString(tok):
+			obs.VisibilitySM = parseVisibilitySM(tok)
+		case visMetersRe.MatchString(tok):
+			obs.VisibilitySM = parseVisibilityMeters(tok)
+		case cloudRe.MatchString(tok):
+			obs.Clouds = append(obs.Clouds, parseCloudLayer(tok))
+		case altimeterRe.MatchString(tok):
+			obs.AltimeterInHg = parseAltimeterInHg(tok)
+		case qnhRe.MatchString(tok):
+			obs.AltimeterInHg = parseQNHInHg(tok)
+		case tempDewpRe.MatchString(tok):
+			obs.TemperatureC, obs.DewpointC = parseTempDewpoint(tok)
+		case wxCodeRe.MatchString(tok):
+			obs.WxCodes = append(obs.WxCodes, tok)
+		}
+	}
+
+	obs.FlightRules = classifyFlightRules(obs.ceilingFeet(), obs.VisibilitySM)
+	return obs, nil
+}
+
+// parseObservationTime reconstructs a timestamp from the DDHHMMZ group,
+// anchored to the current UTC month/year since METAR doesn't encode them.
+func parseObservationTime(tok string) time.Time {
+	m := timeRe.FindStringSubmatch(tok)
+	day, _ := strconv.Atoi(m[1])
+	hour, _ := strconv.Atoi(m[2])
+	min, _ := strconv.Atoi(m[3])
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), day, hour, min, 0, 0, time.UTC)
+}
+
+func parseWind(tok string) Wind {
+	m := windRe.FindStringSubmatch(tok)
+	w := Wind{Variable: m[1] == "VRB"}
+	if !w.Variable {
+		w.DirectionDeg, _ = strconv.Atoi(m[1])
+	}
+	w.SpeedKt, _ = strconv.Atoi(m[2])
+	if m[4] != "" {
+		w.GustKt, _ = strconv.Atoi(m[4])
+	}
+	return w
+}
+
+func parseVisibilitySM(tok string) float64 {
+	m := visSMRe.FindStringSubmatch(tok)
+	whole, _ := strconv.Atoi(m[1])
+	if m[2] == "" {
+		return float64(whole)
+	}
+	// Fractional form, e.g. "1/2SM" (whole is the numerator here).
+	denom, _ := strconv.Atoi(m[2])
+	if denom == 0 {
+		return float64(whole)
+	}
+	return float64(whole) / float64(denom)
+}
+
+// metersPerStatuteMile converts ICAO/meters-format visibility (used by
+// nearly every non-US station) to the statute miles classifyFlightRules
+// expects.
+const metersPerStatuteMile = 1609.344
+
+func parseVisibilityMeters(tok string) float64 {
+	m := visMetersRe.FindStringSubmatch(tok)
+	meters, _ := strconv.Atoi(m[1])
+	return float64(meters) / metersPerStatuteMile
+}
+
+func parseCloudLayer(tok string) CloudLayer {
+	m := cloudRe.FindStringSubmatch(tok)
+	base, _ := strconv.Atoi(m[2])
+	return CloudLayer{Cover: m[1], BaseFeet: base * 100}
+}
+
+func parseAltimeterInHg(tok string) float64 {
+	m := altimeterRe.FindStringSubmatch(tok)
+	v, _ := strconv.Atoi(m[1])
+	return float64(v) / 100
+}
+
+func parseQNHInHg(tok string) float64 {
+	m := qnhRe.FindStringSubmatch(tok)
+	v, _ := strconv.Atoi(m[1])
+	return float64(v) / 33.8639
+}
+
+func parseTempDewpoint(tok string) (temp, dewpoint float64) {
+	m := tempDewpRe.FindStringSubmatch(tok)
+	return parseMinusTemp(m[1]), parseMinusTemp(m[2])
+}
+
+// parseMinusTemp converts a METAR temperature field, where sub-zero values
+// are prefixed with "M" instead of a minus sign.
+func parseMinusTemp(s string) float64 {
+	if strings.HasPrefix(s, "M") {
+		v, _ := strconv.Atoi(s[1:])
+		return -float64(v)
+	}
+	v, _ := strconv.Atoi(s)
+	return float64(v)
+}
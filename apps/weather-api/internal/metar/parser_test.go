@@ -0,0 +1,84 @@
+package metar
+
+import "testing"
+
+func TestParseRawMETARVFR(t *testing.T) {
+	obs, err := ParseRawMETAR("KJFK 261851Z 28016G24KT 10SM FEW050 SCT250 24/18 A3002")
+	if err != nil {
+		t.Fatalf("ParseRawMETAR() error = %v", err)
+	}
+
+	if obs.Station != "KJFK" {
+		t.Errorf("Station = %q, want KJFK", obs.Station)
+	}
+	if obs.Wind.DirectionDeg != 280 || obs.Wind.SpeedKt != 16 || obs.Wind.GustKt != 24 {
+		t.Errorf("Wind = %+v, want {280 false 16 24}", obs.Wind)
+	}
+	if obs.VisibilitySM != 10 {
+		t.Errorf("VisibilitySM = %v, want 10", obs.VisibilitySM)
+	}
+	if len(obs.Clouds) != 2 || obs.Clouds[0].Cover != "FEW" || obs.Clouds[0].BaseFeet != 5000 {
+		t.Errorf("Clouds = %+v, want FEW050/SCT250", obs.Clouds)
+	}
+	if obs.TemperatureC != 24 || obs.DewpointC != 18 {
+		t.Errorf("Temp/Dewpoint = %v/%v, want 24/18", obs.TemperatureC, obs.DewpointC)
+	}
+	if obs.AltimeterInHg != 30.02 {
+		t.Errorf("AltimeterInHg = %v, want 30.02", obs.AltimeterInHg)
+	}
+	if obs.FlightRules != VFR {
+		t.Errorf("FlightRules = %q, want VFR", obs.FlightRules)
+	}
+}
+
+func TestParseRawMETARLIFR(t *testing.T) {
+	obs, err := ParseRawMETAR("KBOS 261851Z 00000KT 1/4SM FG OVC002 05/05 Q1013")
+	if err != nil {
+		t.Fatalf("ParseRawMETAR() error = %v", err)
+	}
+
+	if obs.VisibilitySM != 0.25 {
+		t.Errorf("VisibilitySM = %v, want 0.25", obs.VisibilitySM)
+	}
+	if len(obs.WxCodes) != 1 || obs.WxCodes[0] != "FG" {
+		t.Errorf("WxCodes = %v, want [FG]", obs.WxCodes)
+	}
+	if obs.FlightRules != LIFR {
+		t.Errorf("FlightRules = %q, want LIFR (low ceiling + vis)", obs.FlightRules)
+	}
+}
+
+func TestParseRawMETARNegativeTemp(t *testing.T) {
+	obs, err := ParseRawMETAR("EGLL 261851Z 18005KT 9999 SCT030 M05/M10 A2992")
+	if err != nil {
+		t.Fatalf("ParseRawMETAR() error = %v", err)
+	}
+	if obs.TemperatureC != -5 || obs.DewpointC != -10 {
+		t.Errorf("Temp/Dewpoint = %v/%v, want -5/-10", obs.TemperatureC, obs.DewpointC)
+	}
+	if want := parseVisibilityMeters("9999"); obs.VisibilitySM != want {
+		t.Errorf("VisibilitySM = %v, want %v (9999 meters-format visibility)", obs.VisibilitySM, want)
+	}
+	if obs.FlightRules != VFR {
+		t.Errorf("FlightRules = %q, want VFR for 9999 visibility, got LIFR if meters-format visibility wasn't parsed", obs.FlightRules)
+	}
+}
+
+func TestParseRawMETARMetersVisibilityLowVis(t *testing.T) {
+	obs, err := ParseRawMETAR("LFPG 261851Z 20010KT 0350 FG OVC001 02/02 Q1009")
+	if err != nil {
+		t.Fatalf("ParseRawMETAR() error = %v", err)
+	}
+	if want := parseVisibilityMeters("0350"); obs.VisibilitySM != want {
+		t.Errorf("VisibilitySM = %v, want %v", obs.VisibilitySM, want)
+	}
+	if obs.FlightRules != LIFR {
+		t.Errorf("FlightRules = %q, want LIFR for 350m visibility", obs.FlightRules)
+	}
+}
+
+func TestParseRawMETARTooShort(t *testing.T) {
+	if _, err := ParseRawMETAR("KJFK"); err == nil {
+		t.Error("ParseRawMETAR() error = nil, want error for a too-short report")
+	}
+}
@@ -0,0 +1,163 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Source fetches raw METAR/TAF report text for a station. Concrete
+// implementations differ only in how they reach the upstream - the raw
+// text they return is parsed uniformly by ParseRawMETAR/ParseRawTAF.
+type Source interface {
+	Name() string
+	FetchMETAR(ctx context.Context, station string) (raw string, err error)
+	FetchTAF(ctx context.Context, station string) (raw string, err error)
+}
+
+// Config selects and configures the active Source.
+type Config struct {
+	Upstream    string // noaa | avwx
+	NOAABaseURL string
+	AVWXBaseURL string
+	AVWXAPIKey  string
+}
+
+// NewConfigFromEnv reads AVIATION_UPSTREAM, NOAA_ADDS_BASE_URL,
+// AVWX_BASE_URL, and AVWX_API_KEY.
+func NewConfigFromEnv(getenv func(string) string) Config {
+	cfg := Config{
+		Upstream:    getenv("AVIATION_UPSTREAM"),
+		NOAABaseURL: getenv("NOAA_ADDS_BASE_URL"),
+		AVWXBaseURL: getenv("AVWX_BASE_URL"),
+		AVWXAPIKey:  getenv("AVWX_API_KEY"),
+	}
+	if cfg.Upstream == "" {
+		cfg.Upstream = "noaa"
+	}
+	if cfg.NOAABaseURL == "" {
+		cfg.NOAABaseURL = "https://aviationweather.gov/cgi-bin/data"
+	}
+	if cfg.AVWXBaseURL == "" {
+		cfg.AVWXBaseURL = "https://avwx.rest/api"
+	}
+	return cfg
+}
+
+// NewSource builds the Source selected by cfg.Upstream.
+func NewSource(cfg Config, client *http.Client) Source {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	if cfg.Upstream == "avwx" {
+		return &avwxSource{cfg: cfg, client: client}
+	}
+	return &noaaADDSSource{cfg: cfg, client: client}
+}
+
+// noaaADDSSource fetches raw reports from NOAA's Aviation Digital Data
+// Service text feed, which returns the report as plain text.
+type noaaADDSSource struct {
+	cfg    Config
+	client *http.Client
+}
+
+func (s *noaaADDSSource) Name() string { return "noaa-adds" }
+
+func (s *noaaADDSSource) FetchMETAR(ctx context.Context, station string) (string, error) {
+	return s.fetchRaw(ctx, "metar.php", station)
+}
+
+func (s *noaaADDSSource) FetchTAF(ctx context.Context, station string) (string, error) {
+	return s.fetchRaw(ctx, "taf.php", station)
+}
+
+func (s *noaaADDSSource) fetchRaw(ctx context.Context, endpoint, station string) (string, error) {
+	q := url.Values{}
+	q.Set("ids", station)
+	q.Set("format", "raw")
+
+	reqURL := fmt.Sprintf("%s/%s?%s", s.cfg.NOAABaseURL, endpoint, q.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("noaa-adds: build request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("noaa-adds: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("noaa-adds: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("noaa-adds: read response: %w", err)
+	}
+
+	raw := strings.TrimSpace(string(body))
+	if raw == "" {
+		return "", fmt.Errorf("noaa-adds: no report for station %q", station)
+	}
+	return raw, nil
+}
+
+// avwxSource fetches reports from an AVWX-style JSON API, which wraps the
+// raw report text in a "raw" field alongside a pre-parsed structure we
+// don't use (we re-parse the raw text so both sources share one parser).
+type avwxSource struct {
+	cfg    Config
+	client *http.Client
+}
+
+func (s *avwxSource) Name() string { return "avwx" }
+
+func (s *avwxSource) FetchMETAR(ctx context.Context, station string) (string, error) {
+	return s.fetchRaw(ctx, "metar", station)
+}
+
+func (s *avwxSource) FetchTAF(ctx context.Context, station string) (string, error) {
+	return s.fetchRaw(ctx, "taf", station)
+}
+
+type avwxRawResponse struct {
+	Raw string `json:"raw"`
+}
+
+func (s *avwxSource) fetchRaw(ctx context.Context, endpoint, station string) (string, error) {
+	reqURL := fmt.Sprintf("%s/%s/%s", s.cfg.AVWXBaseURL, endpoint, url.PathEscape(station))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("avwx: build request: %w", err)
+	}
+	if s.cfg.AVWXAPIKey != "" {
+		req.Header.Set("Authorization", "Token "+s.cfg.AVWXAPIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("avwx: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("avwx: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed avwxRawResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("avwx: decode response: %w", err)
+	}
+	if parsed.Raw == "" {
+		return "", fmt.Errorf("avwx: no raw report for station %q", station)
+	}
+	return parsed.Raw, nil
+}
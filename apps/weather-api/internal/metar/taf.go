@@ -0,0 +1,118 @@
+package metar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	tafValidityRe = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z?$`)
+	tafChangeRe   = regexp.MustCompile(`^(FM\d{6}|BECMG|TEMPO|PROB\d{2})`)
+)
+
+// ForecastPeriod is one change group within a TAF - the initial conditions,
+// or a subsequent FM/BECMG/TEMPO/PROB group.
+type ForecastPeriod struct {
+	Change       string       `json:"change,omitempty"` // empty for the initial group
+	From         time.Time    `json:"from,omitempty"`
+	Wind         Wind         `json:"wind"`
+	VisibilitySM float64      `json:"visibility_sm"`
+	Clouds       []CloudLayer `json:"clouds"`
+	WxCodes      []string     `json:"wx_codes,omitempty"`
+}
+
+// TAF is a parsed terminal aerodrome forecast.
+type TAF struct {
+	Station   string           `json:"station"`
+	IssuedAt  time.Time        `json:"issued_at"`
+	ValidFrom time.Time        `json:"valid_from"`
+	ValidTo   time.Time        `json:"valid_to"`
+	Periods   []ForecastPeriod `json:"periods"`
+	RawText   string           `json:"raw_text"`
+}
+
+// ParseRawTAF parses a raw TAF report into its change groups. Like
+// ParseRawMETAR, this covers the fields CloudWeather Sentinel surfaces and
+// does not attempt turbulence, icing, or remarks parsing.
+func ParseRawTAF(raw string) (TAF, error) {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimPrefix(raw, "TAF ")
+	tokens := strings.Fields(raw)
+	if len(tokens) < 3 {
+		return TAF{}, fmt.Errorf("metar: TAF report too short: %q", raw)
+	}
+
+	taf := TAF{Station: tokens[0], RawText: raw}
+
+	idx := 1
+	if m := tafValidityRe.FindStringSubmatch(tokens[idx]); m != nil {
+		taf.IssuedAt = parseTafTime(m)
+		idx++
+	}
+	if idx < len(tokens) && strings.Contains(tokens[idx], "/") {
+		parts := strings.SplitN(tokens[idx], "/", 2)
+		taf.ValidFrom = parseTafValidityEdge(parts[0])
+		taf.ValidTo = parseTafValidityEdge(parts[1])
+		idx++
+	}
+
+	var current *ForecastPeriod
+	taf.Periods = append(taf.Periods, ForecastPeriod{})
+	current = &taf.Periods[len(taf.Periods)-1]
+
+	for _, tok := range tokens[idx:] {
+		if tafChangeRe.MatchString(tok) {
+			taf.Periods = append(taf.Periods, ForecastPeriod{Change: changeName(tok)})
+			current = &taf.Periods[len(taf.Periods)-1]
+			continue
+		}
+		switch {
+		case windRe.MatchString(tok):
+			current.Wind = parseWind(tok)
+		case visSMRe.MatchString(tok):
+			current.VisibilitySM = parseVisibilitySM(tok)
+		case visMetersRe.MatchString(tok):
+			current.VisibilitySM = parseVisibilityMeters(tok)
+		case cloudRe.MatchString(tok):
+			current.Clouds = append(current.Clouds, parseCloudLayer(tok))
+		case wxCodeRe.MatchString(tok):
+			current.WxCodes = append(current.WxCodes, tok)
+		}
+	}
+
+	return taf, nil
+}
+
+func changeName(tok string) string {
+	if strings.HasPrefix(tok, "FM") {
+		return "FM"
+	}
+	if strings.HasPrefix(tok, "PROB") {
+		return tok
+	}
+	return tok
+}
+
+// parseTafTime reconstructs a timestamp from a DDHHMMZ? issue-time group.
+func parseTafTime(m []string) time.Time {
+	day, _ := strconv.Atoi(m[1])
+	hour, _ := strconv.Atoi(m[2])
+	min, _ := strconv.Atoi(m[3])
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), day, hour, min, 0, 0, time.UTC)
+}
+
+// parseTafValidityEdge reconstructs a timestamp from a DDHH validity-period
+// edge, e.g. the "2618" in "2618/2718".
+func parseTafValidityEdge(s string) time.Time {
+	if len(s) != 4 {
+		return time.Time{}
+	}
+	day, _ := strconv.Atoi(s[0:2])
+	hour, _ := strconv.Atoi(s[2:4])
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), day, hour, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,55 @@
+package metar
+
+import "testing"
+
+func TestParseRawTAF(t *testing.T) {
+	taf, err := ParseRawTAF("TAF KJFK 261720Z 2618/2724 28015G25KT 10SM FEW050 FM270100 32012KT 6SM -RA BKN030 TEMPO 2706/2710 3SM BR BKN015")
+	if err != nil {
+		t.Fatalf("ParseRawTAF() error = %v", err)
+	}
+
+	if taf.Station != "KJFK" {
+		t.Errorf("Station = %q, want KJFK", taf.Station)
+	}
+	if len(taf.Periods) != 3 {
+		t.Fatalf("len(Periods) = %d, want 3 (initial, FM, TEMPO)", len(taf.Periods))
+	}
+
+	initial := taf.Periods[0]
+	if initial.Wind.DirectionDeg != 280 || initial.Wind.SpeedKt != 15 || initial.Wind.GustKt != 25 {
+		t.Errorf("initial Wind = %+v, want {280 false 15 25}", initial.Wind)
+	}
+
+	fm := taf.Periods[1]
+	if fm.Change != "FM" {
+		t.Errorf("Periods[1].Change = %q, want FM", fm.Change)
+	}
+	if len(fm.WxCodes) != 1 || fm.WxCodes[0] != "-RA" {
+		t.Errorf("Periods[1].WxCodes = %v, want [-RA]", fm.WxCodes)
+	}
+
+	tempo := taf.Periods[2]
+	if tempo.Change != "TEMPO" {
+		t.Errorf("Periods[2].Change = %q, want TEMPO", tempo.Change)
+	}
+	if tempo.VisibilitySM != 3 {
+		t.Errorf("Periods[2].VisibilitySM = %v, want 3", tempo.VisibilitySM)
+	}
+}
+
+func TestParseRawTAFMetersVisibility(t *testing.T) {
+	taf, err := ParseRawTAF("TAF LFPG 261700Z 2618/2724 20010KT 0350 FG OVC001")
+	if err != nil {
+		t.Fatalf("ParseRawTAF() error = %v", err)
+	}
+
+	if want := parseVisibilityMeters("0350"); taf.Periods[0].VisibilitySM != want {
+		t.Errorf("Periods[0].VisibilitySM = %v, want %v (0350 meters-format visibility)", taf.Periods[0].VisibilitySM, want)
+	}
+}
+
+func TestParseRawTAFTooShort(t *testing.T) {
+	if _, err := ParseRawTAF("TAF KJFK"); err == nil {
+		t.Error("ParseRawTAF() error = nil, want error for a too-short report")
+	}
+}
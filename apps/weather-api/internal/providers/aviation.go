@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// AviationConfig holds the settings for an AVWX-style aviation weather
+// source, used as one input to multi-source aggregation. Full METAR/TAF
+// parsing lives in the metar package; this provider only extracts the
+// handful of fields WeatherData cares about.
+type AviationConfig struct {
+	BaseURL string
+	APIKey  string
+}
+
+// NewAviationConfigFromEnv reads AVWX_BASE_URL and AVWX_API_KEY.
+func NewAviationConfigFromEnv(getenv func(string) string) AviationConfig {
+	cfg := AviationConfig{
+		BaseURL: getenv("AVWX_BASE_URL"),
+		APIKey:  getenv("AVWX_API_KEY"),
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://avwx.rest/api"
+	}
+	return cfg
+}
+
+// AviationProvider implements Provider against an AVWX-style METAR JSON
+// endpoint. It expects loc.Raw to be an ICAO station identifier, e.g.
+// "KJFK" - the same value the /metar/{station} route accepts.
+type AviationProvider struct {
+	cfg    AviationConfig
+	client *http.Client
+}
+
+// NewAviationProvider returns a provider using cfg.
+func NewAviationProvider(cfg AviationConfig, client *http.Client) *AviationProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &AviationProvider{cfg: cfg, client: client}
+}
+
+// Name implements Provider.
+func (p *AviationProvider) Name() string { return "aviation" }
+
+type avwxMetarResponse struct {
+	Station   string `json:"station"`
+	Altimeter struct {
+		Value float64 `json:"value"`
+	} `json:"altimeter"`
+	Temperature struct {
+		Value float64 `json:"value"`
+	} `json:"temperature"`
+	WindSpeed struct {
+		Value float64 `json:"value"`
+	} `json:"wind_speed"`
+	RelativeHumidity float64 `json:"relative_humidity"`
+}
+
+// GetWeather implements Provider. Aviation stations are looked up by ICAO
+// code; the Location's geo/zip fields are ignored.
+func (p *AviationProvider) GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error) {
+	station := loc.City
+	if station == "" {
+		station = loc.Raw
+	}
+
+	reqURL := fmt.Sprintf("%s/metar/%s", p.cfg.BaseURL, url.PathEscape(station))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return weather.WeatherData{}, fmt.Errorf("aviation: build request: %w", err)
+	}
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Token "+p.cfg.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return weather.WeatherData{}, fmt.Errorf("aviation: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return weather.WeatherData{}, fmt.Errorf("aviation: unexpected status %d", resp.StatusCode)
+	}
+
+	var m avwxMetarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return weather.WeatherData{}, fmt.Errorf("aviation: decode response: %w", err)
+	}
+
+	// AVWX reports altimeter in inches of mercury; convert to hPa to match
+	// the rest of the API.
+	pressureHPa := m.Altimeter.Value * 33.8639
+
+	return weather.WeatherData{
+		Location:    m.Station,
+		Temperature: m.Temperature.Value,
+		Humidity:    int(m.RelativeHumidity),
+		Pressure:    pressureHPa,
+		WindSpeed:   m.WindSpeed.Value,
+		Timestamp:   time.Now(),
+		Source:      p.Name(),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// DarkSkyConfig holds the settings for a Dark Sky-compatible endpoint (the
+// original Dark Sky API shape, still served by several drop-in
+// replacements). This source is optional and only used as an extra input
+// to multi-source aggregation.
+type DarkSkyConfig struct {
+	BaseURL string
+	APIKey  string
+}
+
+// NewDarkSkyConfigFromEnv reads DARKSKY_BASE_URL and DARKSKY_API_KEY.
+func NewDarkSkyConfigFromEnv(getenv func(string) string) DarkSkyConfig {
+	return DarkSkyConfig{
+		BaseURL: getenv("DARKSKY_BASE_URL"),
+		APIKey:  getenv("DARKSKY_API_KEY"),
+	}
+}
+
+// DarkSkyProvider implements Provider against a Dark Sky-compatible
+// "forecast" endpoint. It requires a lat/lon Location; city names and
+// zip/country lookups are not supported by the Dark Sky shape.
+type DarkSkyProvider struct {
+	cfg    DarkSkyConfig
+	client *http.Client
+}
+
+// NewDarkSkyProvider returns a provider using cfg.
+func NewDarkSkyProvider(cfg DarkSkyConfig, client *http.Client) *DarkSkyProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &DarkSkyProvider{cfg: cfg, client: client}
+}
+
+// Name implements Provider.
+func (p *DarkSkyProvider) Name() string { return "darksky" }
+
+type darkSkyResponse struct {
+	Currently struct {
+		Temperature float64 `json:"temperature"`
+		Humidity    float64 `json:"humidity"`
+		Pressure    float64 `json:"pressure"`
+		WindSpeed   float64 `json:"windSpeed"`
+	} `json:"currently"`
+}
+
+// GetWeather implements Provider.
+func (p *DarkSkyProvider) GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error) {
+	if !loc.HasGeo {
+		return weather.WeatherData{}, fmt.Errorf("darksky: requires a lat/lon location")
+	}
+
+	reqURL := fmt.Sprintf("%s/%s/%f,%f", p.cfg.BaseURL, p.cfg.APIKey, loc.Lat, loc.Lon)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return weather.WeatherData{}, fmt.Errorf("darksky: build request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return weather.WeatherData{}, fmt.Errorf("darksky: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return weather.WeatherData{}, fmt.Errorf("darksky: unexpected status %d", resp.StatusCode)
+	}
+
+	var ds darkSkyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ds); err != nil {
+		return weather.WeatherData{}, fmt.Errorf("darksky: decode response: %w", err)
+	}
+
+	return weather.WeatherData{
+		Location:    loc.Raw,
+		Temperature: ds.Currently.Temperature,
+		Humidity:    int(ds.Currently.Humidity * 100),
+		Pressure:    ds.Currently.Pressure,
+		WindSpeed:   ds.Currently.WindSpeed,
+		Timestamp:   time.Now(),
+		Source:      p.Name(),
+	}, nil
+}
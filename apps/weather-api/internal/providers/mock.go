@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// MockProvider serves canned weather.WeatherData from a local JSON file,
+// keyed by location. It exists so the Provider abstraction can be exercised
+// in tests and local development without calling a real upstream, and
+// stands in for alternative sources like Meteologix.
+type MockProvider struct {
+	name string
+	data map[string]weather.WeatherData
+}
+
+// NewMockProvider loads a JSON file mapping location strings to
+// weather.WeatherData fixtures.
+func NewMockProvider(name, fixturePath string) (*MockProvider, error) {
+	f, err := os.Open(fixturePath)
+	if err != nil {
+		return nil, fmt.Errorf("mock provider: open fixture: %w", err)
+	}
+	defer f.Close()
+
+	var data map[string]weather.WeatherData
+	if err := json.NewDecoder(f).Decode(&data); err != nil {
+		return nil, fmt.Errorf("mock provider: decode fixture: %w", err)
+	}
+
+	return &MockProvider{name: name, data: data}, nil
+}
+
+// Name implements Provider.
+func (p *MockProvider) Name() string { return p.name }
+
+// GetWeather implements Provider.
+func (p *MockProvider) GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error) {
+	d, ok := p.data[loc.Raw]
+	if !ok {
+		return weather.WeatherData{}, fmt.Errorf("mock provider: no fixture for %q", loc.Raw)
+	}
+	d.Timestamp = time.Now()
+	d.Source = p.name
+	return d, nil
+}
@@ -0,0 +1,39 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+func TestMockProviderGetWeather(t *testing.T) {
+	p, err := NewMockProvider("mock", "../../testdata/mock_weather.json")
+	if err != nil {
+		t.Fatalf("NewMockProvider() error = %v", err)
+	}
+
+	var _ Provider = p
+
+	got, err := p.GetWeather(context.Background(), weather.Location{Raw: "london"})
+	if err != nil {
+		t.Fatalf("GetWeather() error = %v", err)
+	}
+	if got.Location != "London" {
+		t.Errorf("Location = %q, want %q", got.Location, "London")
+	}
+	if got.Source != "mock" {
+		t.Errorf("Source = %q, want %q", got.Source, "mock")
+	}
+}
+
+func TestMockProviderGetWeatherUnknownLocation(t *testing.T) {
+	p, err := NewMockProvider("mock", "../../testdata/mock_weather.json")
+	if err != nil {
+		t.Fatalf("NewMockProvider() error = %v", err)
+	}
+
+	if _, err := p.GetWeather(context.Background(), weather.Location{Raw: "atlantis"}); err == nil {
+		t.Error("GetWeather() error = nil, want error for unknown location")
+	}
+}
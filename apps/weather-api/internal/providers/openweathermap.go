@@ -0,0 +1,319 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// OpenWeatherMapConfig holds the settings needed to talk to the OpenWeatherMap
+// current-conditions API. All fields are typically populated from
+// environment variables; see NewOpenWeatherMapConfigFromEnv.
+type OpenWeatherMapConfig struct {
+	APIKey         string
+	BaseURL        string
+	OneCallBaseURL string
+	Units          string // metric | imperial | standard
+	Lang           string
+}
+
+// NewOpenWeatherMapConfigFromEnv reads OWM_API_KEY, OWM_BASE_URL,
+// OWM_ONECALL_BASE_URL, OWM_UNITS, and OWM_LANG, applying OpenWeatherMap's
+// own defaults where unset.
+func NewOpenWeatherMapConfigFromEnv(getenv func(string) string) OpenWeatherMapConfig {
+	cfg := OpenWeatherMapConfig{
+		APIKey:         getenv("OWM_API_KEY"),
+		BaseURL:        getenv("OWM_BASE_URL"),
+		OneCallBaseURL: getenv("OWM_ONECALL_BASE_URL"),
+		Units:          getenv("OWM_UNITS"),
+		Lang:           getenv("OWM_LANG"),
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.openweathermap.org/data/2.5"
+	}
+	if cfg.OneCallBaseURL == "" {
+		cfg.OneCallBaseURL = "https://api.openweathermap.org/data/3.0/onecall"
+	}
+	if cfg.Units == "" {
+		cfg.Units = "metric"
+	}
+	if cfg.Lang == "" {
+		cfg.Lang = "en"
+	}
+	return cfg
+}
+
+// OpenWeatherMapProvider implements Provider against the real OpenWeatherMap
+// "weather" endpoint, supporting lookup by city name, zip/country, or
+// lat/lon.
+type OpenWeatherMapProvider struct {
+	cfg    OpenWeatherMapConfig
+	client *http.Client
+}
+
+// NewOpenWeatherMapProvider returns a provider using cfg, making requests
+// with client (or a new http.Client with a 5s timeout if nil).
+func NewOpenWeatherMapProvider(cfg OpenWeatherMapConfig, client *http.Client) *OpenWeatherMapProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &OpenWeatherMapProvider{cfg: cfg, client: client}
+}
+
+// Name implements Provider.
+func (p *OpenWeatherMapProvider) Name() string { return "openweathermap" }
+
+type owmResponse struct {
+	Main struct {
+		Temp     float64 `json:"temp"`
+		Humidity int     `json:"humidity"`
+		Pressure float64 `json:"pressure"`
+	} `json:"main"`
+	Wind struct {
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Name string `json:"name"`
+	Cod  int    `json:"cod"`
+}
+
+// GetWeather implements Provider.
+func (p *OpenWeatherMapProvider) GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error) {
+	q := url.Values{}
+	q.Set("appid", p.cfg.APIKey)
+	q.Set("units", p.cfg.Units)
+	q.Set("lang", p.cfg.Lang)
+
+	switch {
+	case loc.HasGeo:
+		q.Set("lat", fmt.Sprintf("%f", loc.Lat))
+		q.Set("lon", fmt.Sprintf("%f", loc.Lon))
+	case loc.Zip != "":
+		zip := loc.Zip
+		if loc.Country != "" {
+			zip = fmt.Sprintf("%s,%s", loc.Zip, loc.Country)
+		}
+		q.Set("zip", zip)
+	default:
+		q.Set("q", loc.City)
+	}
+
+	reqURL := fmt.Sprintf("%s/weather?%s", p.cfg.BaseURL, q.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return weather.WeatherData{}, fmt.Errorf("openweathermap: build request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return weather.WeatherData{}, fmt.Errorf("openweathermap: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return weather.WeatherData{}, fmt.Errorf("openweathermap: unexpected status %d", resp.StatusCode)
+	}
+
+	var owm owmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&owm); err != nil {
+		return weather.WeatherData{}, fmt.Errorf("openweathermap: decode response: %w", err)
+	}
+
+	name := owm.Name
+	if name == "" {
+		name = loc.Raw
+	}
+
+	return weather.WeatherData{
+		Location:    name,
+		Temperature: owm.Main.Temp,
+		Humidity:    owm.Main.Humidity,
+		Pressure:    owm.Main.Pressure,
+		WindSpeed:   owm.Wind.Speed,
+		Timestamp:   time.Now(),
+		Source:      p.Name(),
+	}, nil
+}
+
+type owmWeatherCondition struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+type owmPrecipitation struct {
+	OneHour float64 `json:"1h"`
+}
+
+type owmHourly struct {
+	Dt        int64                 `json:"dt"`
+	Temp      float64               `json:"temp"`
+	FeelsLike float64               `json:"feels_like"`
+	Pressure  float64               `json:"pressure"`
+	Humidity  int                   `json:"humidity"`
+	Clouds    int                   `json:"clouds"`
+	WindSpeed float64               `json:"wind_speed"`
+	WindDeg   int                   `json:"wind_deg"`
+	Pop       float64               `json:"pop"`
+	Rain      owmPrecipitation      `json:"rain"`
+	Snow      owmPrecipitation      `json:"snow"`
+	Weather   []owmWeatherCondition `json:"weather"`
+}
+
+type owmDailyTemp struct {
+	Morn  float64 `json:"morn"`
+	Day   float64 `json:"day"`
+	Eve   float64 `json:"eve"`
+	Night float64 `json:"night"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+}
+
+type owmDaily struct {
+	Dt        int64                 `json:"dt"`
+	Sunrise   int64                 `json:"sunrise"`
+	Sunset    int64                 `json:"sunset"`
+	Temp      owmDailyTemp          `json:"temp"`
+	FeelsLike owmDailyTemp          `json:"feels_like"`
+	Pressure  float64               `json:"pressure"`
+	Humidity  int                   `json:"humidity"`
+	Clouds    int                   `json:"clouds"`
+	WindSpeed float64               `json:"wind_speed"`
+	WindDeg   int                   `json:"wind_deg"`
+	Pop       float64               `json:"pop"`
+	Rain      float64               `json:"rain"`
+	Snow      float64               `json:"snow"`
+	Uvi       float64               `json:"uvi"`
+	Weather   []owmWeatherCondition `json:"weather"`
+}
+
+type owmOneCallResponse struct {
+	TimezoneOffset int `json:"timezone_offset"`
+	Current        struct {
+		Sunrise int64   `json:"sunrise"`
+		Sunset  int64   `json:"sunset"`
+		Uvi     float64 `json:"uvi"`
+	} `json:"current"`
+	Hourly []owmHourly `json:"hourly"`
+	Daily  []owmDaily  `json:"daily"`
+}
+
+func owmCondition(conds []owmWeatherCondition) weather.Condition {
+	if len(conds) == 0 {
+		return weather.Condition{}
+	}
+	return weather.Condition{Code: conds[0].ID, Description: conds[0].Description}
+}
+
+// GetForecast implements ForecastProvider using OpenWeatherMap's OneCall
+// API. It requires a lat/lon Location; OneCall doesn't accept city names,
+// zip codes, or station identifiers.
+func (p *OpenWeatherMapProvider) GetForecast(ctx context.Context, loc weather.Location, opts ForecastOptions) (weather.Forecast, error) {
+	if !loc.HasGeo {
+		return weather.Forecast{}, fmt.Errorf("openweathermap: %w", ErrGeoRequired)
+	}
+
+	units, lang := opts.Units, opts.Lang
+	if units == "" {
+		units = p.cfg.Units
+	}
+	if lang == "" {
+		lang = p.cfg.Lang
+	}
+
+	q := url.Values{}
+	q.Set("appid", p.cfg.APIKey)
+	q.Set("units", units)
+	q.Set("lang", lang)
+	q.Set("lat", fmt.Sprintf("%f", loc.Lat))
+	q.Set("lon", fmt.Sprintf("%f", loc.Lon))
+	q.Set("exclude", "minutely,alerts")
+
+	reqURL := fmt.Sprintf("%s?%s", p.cfg.OneCallBaseURL, q.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return weather.Forecast{}, fmt.Errorf("openweathermap: build forecast request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return weather.Forecast{}, fmt.Errorf("openweathermap: forecast request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return weather.Forecast{}, fmt.Errorf("openweathermap: unexpected forecast status %d", resp.StatusCode)
+	}
+
+	var owm owmOneCallResponse
+	if err := json.NewDecoder(resp.Body).Decode(&owm); err != nil {
+		return weather.Forecast{}, fmt.Errorf("openweathermap: decode forecast response: %w", err)
+	}
+
+	hours := opts.Hours
+	if hours <= 0 || hours > len(owm.Hourly) {
+		hours = len(owm.Hourly)
+	}
+	hourly := make([]weather.HourlyForecast, 0, hours)
+	for _, h := range owm.Hourly[:hours] {
+		hourly = append(hourly, weather.HourlyForecast{
+			Timestamp:  time.Unix(h.Dt, 0),
+			Temp:       h.Temp,
+			FeelsLike:  h.FeelsLike,
+			Pressure:   h.Pressure,
+			Humidity:   h.Humidity,
+			WindSpeed:  h.WindSpeed,
+			WindDeg:    h.WindDeg,
+			Clouds:     h.Clouds,
+			Pop:        h.Pop,
+			RainVolume: h.Rain.OneHour,
+			SnowVolume: h.Snow.OneHour,
+			Condition:  owmCondition(h.Weather),
+		})
+	}
+
+	days := opts.Days
+	if days <= 0 || days > len(owm.Daily) {
+		days = len(owm.Daily)
+	}
+	daily := make([]weather.DailyForecast, 0, days)
+	for _, d := range owm.Daily[:days] {
+		daily = append(daily, weather.DailyForecast{
+			Timestamp: time.Unix(d.Dt, 0),
+			Sunrise:   time.Unix(d.Sunrise, 0),
+			Sunset:    time.Unix(d.Sunset, 0),
+			Temp: weather.DailyTemperature{
+				Morn: d.Temp.Morn, Day: d.Temp.Day, Eve: d.Temp.Eve,
+				Night: d.Temp.Night, Min: d.Temp.Min, Max: d.Temp.Max,
+			},
+			FeelsLike: weather.DailyTemperature{
+				Morn: d.FeelsLike.Morn, Day: d.FeelsLike.Day, Eve: d.FeelsLike.Eve,
+				Night: d.FeelsLike.Night, Min: d.FeelsLike.Min, Max: d.FeelsLike.Max,
+			},
+			Pressure:   d.Pressure,
+			Humidity:   d.Humidity,
+			WindSpeed:  d.WindSpeed,
+			WindDeg:    d.WindDeg,
+			Clouds:     d.Clouds,
+			Pop:        d.Pop,
+			RainVolume: d.Rain,
+			SnowVolume: d.Snow,
+			UVIndex:    d.Uvi,
+			Condition:  owmCondition(d.Weather),
+		})
+	}
+
+	return weather.Forecast{
+		Location:       loc.Raw,
+		TimezoneOffset: owm.TimezoneOffset,
+		Sunrise:        time.Unix(owm.Current.Sunrise, 0),
+		Sunset:         time.Unix(owm.Current.Sunset, 0),
+		UVIndex:        owm.Current.Uvi,
+		Hourly:         hourly,
+		Daily:          daily,
+		Source:         p.Name(),
+	}, nil
+}
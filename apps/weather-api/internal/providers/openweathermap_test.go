@@ -0,0 +1,64 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+func TestOpenWeatherMapGetForecast(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"timezone_offset": -18000,
+			"current": {"sunrise": 1700000000, "sunset": 1700040000, "uvi": 3.2},
+			"hourly": [
+				{"dt": 1700000000, "temp": 18.5, "feels_like": 17.9, "pressure": 1012, "humidity": 55, "clouds": 20, "wind_speed": 4.1, "wind_deg": 200, "pop": 0.1, "weather": [{"id": 800, "description": "clear sky"}]},
+				{"dt": 1700003600, "temp": 17.2, "feels_like": 16.8, "pressure": 1013, "humidity": 58, "clouds": 30, "wind_speed": 3.9, "wind_deg": 190, "pop": 0.2, "weather": [{"id": 801, "description": "few clouds"}]}
+			],
+			"daily": [
+				{"dt": 1700000000, "sunrise": 1700000000, "sunset": 1700040000, "temp": {"morn": 12, "day": 20, "eve": 16, "night": 10, "min": 9, "max": 21}, "feels_like": {"morn": 11, "day": 19, "eve": 15, "night": 9}, "pressure": 1012, "humidity": 55, "clouds": 20, "wind_speed": 4.1, "wind_deg": 200, "pop": 0.1, "uvi": 4.5, "weather": [{"id": 800, "description": "clear sky"}]}
+			]
+		}`))
+	}))
+	defer srv.Close()
+
+	cfg := OpenWeatherMapConfig{APIKey: "test", OneCallBaseURL: srv.URL, Units: "metric", Lang: "en"}
+	p := NewOpenWeatherMapProvider(cfg, srv.Client())
+
+	forecast, err := p.GetForecast(context.Background(), weather.Location{Raw: "51.5,-0.12", Lat: 51.5, Lon: -0.12, HasGeo: true}, ForecastOptions{Hours: 1, Days: 1})
+	if err != nil {
+		t.Fatalf("GetForecast() error = %v", err)
+	}
+
+	if len(forecast.Hourly) != 1 {
+		t.Fatalf("len(Hourly) = %d, want 1 (respecting opts.Hours)", len(forecast.Hourly))
+	}
+	if forecast.Hourly[0].Temp != 18.5 {
+		t.Errorf("Hourly[0].Temp = %v, want 18.5", forecast.Hourly[0].Temp)
+	}
+	if len(forecast.Daily) != 1 {
+		t.Fatalf("len(Daily) = %d, want 1", len(forecast.Daily))
+	}
+	if forecast.Daily[0].UVIndex != 4.5 {
+		t.Errorf("Daily[0].UVIndex = %v, want 4.5", forecast.Daily[0].UVIndex)
+	}
+	if forecast.TimezoneOffset != -18000 {
+		t.Errorf("TimezoneOffset = %d, want -18000", forecast.TimezoneOffset)
+	}
+}
+
+func TestOpenWeatherMapGetForecastRequiresGeo(t *testing.T) {
+	p := NewOpenWeatherMapProvider(OpenWeatherMapConfig{}, nil)
+	_, err := p.GetForecast(context.Background(), weather.Location{Raw: "london", City: "london"}, ForecastOptions{})
+	if err == nil {
+		t.Fatal("GetForecast() error = nil, want error for non-geo location")
+	}
+	if !errors.Is(err, ErrGeoRequired) {
+		t.Errorf("GetForecast() error = %v, want it to wrap ErrGeoRequired so callers can map it to a 400", err)
+	}
+}
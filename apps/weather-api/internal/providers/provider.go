@@ -0,0 +1,43 @@
+// Package providers defines the pluggable upstream weather source
+// abstraction and the concrete implementations behind it.
+package providers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
+)
+
+// ErrGeoRequired is returned by ForecastProvider.GetForecast when called
+// with a Location that didn't resolve to a lat/lon, e.g. a bare city name
+// or zip/country pair. It's a client input error, not an upstream
+// failure, so callers should map it to a 4xx response rather than 502.
+var ErrGeoRequired = errors.New("providers: forecast requires a lat/lon location")
+
+// Provider fetches current conditions for a Location from a single
+// upstream weather source.
+type Provider interface {
+	// Name identifies the provider, e.g. for metrics labels and the
+	// response Source field.
+	Name() string
+	GetWeather(ctx context.Context, loc weather.Location) (weather.WeatherData, error)
+}
+
+// ForecastOptions controls how much forecast data to return and in what
+// units/language, mirroring the /forecast route's query parameters.
+type ForecastOptions struct {
+	Hours int
+	Days  int
+	Units string // metric | imperial | standard; empty uses the provider's configured default
+	Lang  string // empty uses the provider's configured default
+}
+
+// ForecastProvider is implemented by providers that can also serve
+// hourly/daily forecasts, in addition to current conditions. Not every
+// Provider supports this - aviation and Dark Sky-style sources in this
+// codebase only implement Provider.
+type ForecastProvider interface {
+	Provider
+	GetForecast(ctx context.Context, loc weather.Location, opts ForecastOptions) (weather.Forecast, error)
+}
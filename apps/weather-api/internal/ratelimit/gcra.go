@@ -0,0 +1,57 @@
+// Package ratelimit implements a GCRA (generic cell rate algorithm) limiter,
+// used to keep upstream provider calls within quota on a per-path basis.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// GCRA is a per-key rate limiter implementing the generic cell rate
+// algorithm: it allows rate requests per period on average, with burst
+// additional requests permitted in a row.
+type GCRA struct {
+	mu          sync.Mutex
+	emissionGap time.Duration
+	burstOffset time.Duration
+	tat         map[string]time.Time
+	now         func() time.Time
+}
+
+// New returns a GCRA limiter allowing rate events per period, with burst
+// extra events permitted immediately.
+func New(rate int, period time.Duration, burst int) *GCRA {
+	if rate <= 0 {
+		rate = 1
+	}
+	emissionGap := period / time.Duration(rate)
+	return &GCRA{
+		emissionGap: emissionGap,
+		burstOffset: emissionGap * time.Duration(burst),
+		tat:         make(map[string]time.Time),
+		now:         time.Now,
+	}
+}
+
+// Allow reports whether a request keyed by key is permitted right now,
+// updating the limiter's internal state as a side effect.
+func (g *GCRA) Allow(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	tat, ok := g.tat[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(g.emissionGap)
+	// The request is allowed as long as it stays within one emission gap
+	// plus the configured burst allowance of the theoretical arrival time.
+	if newTat.Sub(now) > g.emissionGap+g.burstOffset {
+		return false
+	}
+
+	g.tat[key] = newTat
+	return true
+}
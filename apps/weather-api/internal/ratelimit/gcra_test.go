@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGCRAAllowsBurstThenThrottles(t *testing.T) {
+	g := New(10, time.Second, 2) // 10/s steady-state, 2 extra burst requests
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return base }
+
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if g.Allow("key") {
+			allowed++
+		}
+	}
+	if allowed != 3 { // 1 steady-state slot + 2 burst, all at the same instant
+		t.Errorf("allowed = %d, want 3", allowed)
+	}
+
+	g.now = func() time.Time { return base.Add(time.Second) }
+	if !g.Allow("key") {
+		t.Error("Allow() after the refill period = false, want true")
+	}
+}
+
+func TestGCRAPerKeyIsolation(t *testing.T) {
+	g := New(1, time.Second, 0)
+
+	if !g.Allow("a") {
+		t.Error("Allow(a) first call = false, want true")
+	}
+	if !g.Allow("b") {
+		t.Error("Allow(b) first call = false, want true (separate key)")
+	}
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import "time"
+
+// TierLimiter rate-limits per-subject, applying a separate GCRA quota
+// depending on the subject's tier. It's used to give premium JWT claims a
+// larger quota than free ones while keeping per-subject isolation.
+type TierLimiter struct {
+	limiters map[string]*GCRA
+}
+
+// TierQuota describes the rate/period/burst for a single tier.
+type TierQuota struct {
+	Tier   string
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// NewTierLimiter returns a TierLimiter with one independent GCRA per quota.
+// A subject's tier that has no matching quota falls back to the quota
+// whose Tier is TierFreeQuota; if there is none, such requests are allowed.
+func NewTierLimiter(quotas ...TierQuota) *TierLimiter {
+	limiters := make(map[string]*GCRA, len(quotas))
+	for _, q := range quotas {
+		limiters[q.Tier] = New(q.Rate, q.Period, q.Burst)
+	}
+	return &TierLimiter{limiters: limiters}
+}
+
+// Allow reports whether subject is permitted another request under tier's
+// quota, falling back to the free quota if tier is unrecognized.
+func (t *TierLimiter) Allow(subject, tier string) bool {
+	limiter, ok := t.limiters[tier]
+	if !ok {
+		limiter = t.limiters[TierFreeQuota]
+	}
+	if limiter == nil {
+		return true
+	}
+	return limiter.Allow(subject)
+}
+
+// TierFreeQuota is the quota key used as the fallback when a subject's
+// tier doesn't match any configured quota.
+const TierFreeQuota = "free"
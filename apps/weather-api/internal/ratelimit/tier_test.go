@@ -0,0 +1,45 @@
+package ratelimit
+
+import "testing"
+
+import "time"
+
+func TestTierLimiterSeparatesQuotasAndSubjects(t *testing.T) {
+	limiter := NewTierLimiter(
+		TierQuota{Tier: "free", Rate: 1, Period: time.Hour, Burst: 0},
+		TierQuota{Tier: "premium", Rate: 1, Period: time.Hour, Burst: 4},
+	)
+
+	if !limiter.Allow("user-free", "free") {
+		t.Error("Allow(user-free, free) first call = false, want true")
+	}
+	if limiter.Allow("user-free", "free") {
+		t.Error("Allow(user-free, free) second call = true, want false (rate 1/period exhausted)")
+	}
+
+	// A different subject under the same tier is isolated.
+	if !limiter.Allow("another-user", "free") {
+		t.Error("Allow(another-user, free) = false, want true (separate subject)")
+	}
+
+	// Premium has a larger quota and is unaffected by the free exhaustion above.
+	for i := 0; i < 5; i++ {
+		if !limiter.Allow("user-premium", "premium") {
+			t.Errorf("Allow(user-premium, premium) call %d = false, want true", i)
+		}
+	}
+}
+
+func TestTierLimiterUnknownTierFallsBackToFree(t *testing.T) {
+	limiter := NewTierLimiter(
+		TierQuota{Tier: "free", Rate: 1, Period: time.Hour, Burst: 0},
+		TierQuota{Tier: "premium", Rate: 5, Period: time.Hour, Burst: 0},
+	)
+
+	if !limiter.Allow("user-1", "unrecognized") {
+		t.Error("Allow() with an unrecognized tier first call = false, want true (falls back to free)")
+	}
+	if limiter.Allow("user-1", "unrecognized") {
+		t.Error("Allow() with an unrecognized tier second call = true, want false (free quota exhausted)")
+	}
+}
@@ -0,0 +1,70 @@
+package weather
+
+import "time"
+
+// Condition is a provider-agnostic weather condition code and human
+// readable description, mirroring OpenWeatherMap's "weather" object shape.
+type Condition struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+// HourlyForecast is one hour of a OneCall-style hourly forecast.
+type HourlyForecast struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Temp       float64   `json:"temp"`
+	FeelsLike  float64   `json:"feels_like"`
+	Pressure   float64   `json:"pressure"`
+	Humidity   int       `json:"humidity"`
+	WindSpeed  float64   `json:"wind_speed"`
+	WindDeg    int       `json:"wind_deg"`
+	Clouds     int       `json:"clouds"`
+	Pop        float64   `json:"pop"`
+	RainVolume float64   `json:"rain_mm,omitempty"`
+	SnowVolume float64   `json:"snow_mm,omitempty"`
+	Condition  Condition `json:"condition"`
+}
+
+// DailyTemperature breaks a daily forecast's temperature out by time of day,
+// matching OneCall's daily.temp object.
+type DailyTemperature struct {
+	Morn  float64 `json:"morn"`
+	Day   float64 `json:"day"`
+	Eve   float64 `json:"eve"`
+	Night float64 `json:"night"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+}
+
+// DailyForecast is one day of a OneCall-style daily forecast.
+type DailyForecast struct {
+	Timestamp  time.Time        `json:"timestamp"`
+	Sunrise    time.Time        `json:"sunrise"`
+	Sunset     time.Time        `json:"sunset"`
+	Temp       DailyTemperature `json:"temp"`
+	FeelsLike  DailyTemperature `json:"feels_like"`
+	Pressure   float64          `json:"pressure"`
+	Humidity   int              `json:"humidity"`
+	WindSpeed  float64          `json:"wind_speed"`
+	WindDeg    int              `json:"wind_deg"`
+	Clouds     int              `json:"clouds"`
+	Pop        float64          `json:"pop"`
+	RainVolume float64          `json:"rain_mm,omitempty"`
+	SnowVolume float64          `json:"snow_mm,omitempty"`
+	UVIndex    float64          `json:"uv_index"`
+	Condition  Condition        `json:"condition"`
+}
+
+// Forecast is the response shape for the /forecast endpoint: hourly and
+// daily outlooks plus the location metadata needed to interpret their
+// timestamps.
+type Forecast struct {
+	Location       string           `json:"location"`
+	TimezoneOffset int              `json:"timezone_offset"`
+	Sunrise        time.Time        `json:"sunrise"`
+	Sunset         time.Time        `json:"sunset"`
+	UVIndex        float64          `json:"uv_index"`
+	Hourly         []HourlyForecast `json:"hourly"`
+	Daily          []DailyForecast  `json:"daily"`
+	Source         string           `json:"source"`
+}
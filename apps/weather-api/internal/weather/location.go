@@ -0,0 +1,52 @@
+package weather
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseLocation builds a Location from the `{location}` path variable plus
+// any of the `zip`, `country`, `lat`, and `lon` query parameters. The path
+// variable is treated as a city name unless it parses cleanly as a
+// "lat,lon" pair, e.g. "/weather/51.5,-0.12", or the query supplies valid
+// lat/lon or a zip.
+func ParseLocation(pathVar string, query url.Values) Location {
+	loc := Location{Raw: pathVar}
+
+	if lat, lon, ok := parseLatLon(pathVar); ok {
+		loc.Lat, loc.Lon, loc.HasGeo = lat, lon, true
+		return loc
+	}
+
+	if latStr, lonStr := query.Get("lat"), query.Get("lon"); latStr != "" && lonStr != "" {
+		lat, latErr := strconv.ParseFloat(latStr, 64)
+		lon, lonErr := strconv.ParseFloat(lonStr, 64)
+		if latErr == nil && lonErr == nil {
+			loc.Lat, loc.Lon, loc.HasGeo = lat, lon, true
+			return loc
+		}
+	}
+
+	if zip := query.Get("zip"); zip != "" {
+		loc.Zip = zip
+		loc.Country = query.Get("country")
+		return loc
+	}
+
+	loc.City = pathVar
+	return loc
+}
+
+func parseLatLon(s string) (lat, lon float64, ok bool) {
+	parts := strings.SplitN(s, ",", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if latErr != nil || lonErr != nil {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
@@ -0,0 +1,30 @@
+package weather
+
+import (
+	"net/url"
+	"testing"
+)
+
+func mustQuery(raw string) url.Values {
+	v, err := url.ParseQuery(raw)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestCacheKeyDistinguishesQueryParams(t *testing.T) {
+	a := ParseLocation("home", mustQuery("zip=10001&country=US"))
+	b := ParseLocation("home", mustQuery("zip=20002&country=FR"))
+
+	if a.CacheKey() == b.CacheKey() {
+		t.Errorf("CacheKey() collided for different zip/country: %q", a.CacheKey())
+	}
+
+	c := ParseLocation("home", mustQuery("lat=51.5&lon=-0.12"))
+	d := ParseLocation("home", mustQuery("lat=40.7&lon=-74.0"))
+
+	if c.CacheKey() == d.CacheKey() {
+		t.Errorf("CacheKey() collided for different lat/lon: %q", c.CacheKey())
+	}
+}
@@ -0,0 +1,43 @@
+// Package weather holds the data types shared between the HTTP layer and
+// the provider implementations.
+package weather
+
+import (
+	"fmt"
+	"time"
+)
+
+// WeatherData is the normalized response returned to API callers regardless
+// of which upstream provider produced it.
+type WeatherData struct {
+	Location    string    `json:"location"`
+	Temperature float64   `json:"temperature"`
+	Humidity    int       `json:"humidity"`
+	Pressure    float64   `json:"pressure"`
+	WindSpeed   float64   `json:"wind_speed"`
+	Timestamp   time.Time `json:"timestamp"`
+	Source      string    `json:"source"`
+}
+
+// Location identifies where a weather observation should be taken from. A
+// request may specify a free-form city name, a zip/country pair, or a
+// lat/lon coordinate; providers are expected to support whichever subset
+// makes sense for their upstream API.
+type Location struct {
+	Raw     string
+	City    string
+	Zip     string
+	Country string
+	Lat     float64
+	Lon     float64
+	HasGeo  bool
+}
+
+// CacheKey returns a stable string to key cache/rate-limit state on. It
+// incorporates every field ParseLocation can populate from query params
+// (zip+country, lat+lon), not just Raw/City, so two requests that share a
+// path variable but resolve to different places via query params don't
+// collide on the same cache entry.
+func (l Location) CacheKey() string {
+	return fmt.Sprintf("%s|%s|%s|%s|%g|%g|%t", l.Raw, l.City, l.Zip, l.Country, l.Lat, l.Lon, l.HasGeo)
+}
@@ -1,35 +1,81 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/justinas/nosurf"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/aggregator"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/auth"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/cache"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/collector"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/corsmw"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/influx"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/metar"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/providers"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/ratelimit"
+	"github.com/1234-ad/cloudweather-sentinel/apps/weather-api/internal/weather"
 )
 
-type WeatherData struct {
-	Location    string    `json:"location"`
-	Temperature float64   `json:"temperature"`
-	Humidity    int       `json:"humidity"`
-	Pressure    float64   `json:"pressure"`
-	WindSpeed   float64   `json:"wind_speed"`
-	Timestamp   time.Time `json:"timestamp"`
-	Source      string    `json:"source"`
-}
+const (
+	weatherCacheTTL   = 10 * time.Minute
+	weatherCacheSize  = 1000
+	weatherRatePeriod = time.Minute
+	weatherRateBurst  = 5
+	weatherRatePerMin = 60
 
+	freeTierRatePerMin    = 30
+	premiumTierRatePerMin = 300
+	tierRateBurst         = 10
+)
+
+// weatherRouteLimiterKey and forecastRouteLimiterKey are the GCRA keys
+// for ws.limiter. They're fixed per-route, not derived from the request
+// (e.g. the URL path), so every call to a route shares one limiter
+// bucket - the limiter protects the aggregate upstream budget for that
+// route, not just repeat lookups of the same location, which a client
+// could trivially dodge by varying the location per request.
+const (
+	weatherRouteLimiterKey  = "weather"
+	forecastRouteLimiterKey = "forecast"
+)
+
+// WeatherService serves the HTTP weather endpoints, backed by an
+// aggregator.Aggregator that fans each lookup out to every configured
+// providers.Provider, an in-memory response cache, and a per-route rate
+// limiter that protects upstream provider quotas.
 type WeatherService struct {
+	aggregator       *aggregator.Aggregator
+	forecastProvider providers.ForecastProvider
+	cache            *cache.TTLLRU
+	limiter          *ratelimit.GCRA
+
 	requestCounter prometheus.Counter
 	responseTime   prometheus.Histogram
 }
 
-func NewWeatherService() *WeatherService {
+// NewWeatherService returns a WeatherService that fetches current
+// conditions from agg and, if non-nil, forecasts from forecastProvider.
+// Both share the same response cache and rate limiter.
+func NewWeatherService(agg *aggregator.Aggregator, forecastProvider providers.ForecastProvider) *WeatherService {
 	return &WeatherService{
+		aggregator:       agg,
+		forecastProvider: forecastProvider,
+		cache:            cache.New(weatherCacheSize, weatherCacheTTL),
+		limiter:          ratelimit.New(weatherRatePerMin, weatherRatePeriod, weatherRateBurst),
 		requestCounter: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "weather_api_requests_total",
 			Help: "Total number of weather API requests",
@@ -46,25 +92,95 @@ func (ws *WeatherService) GetWeather(w http.ResponseWriter, r *http.Request) {
 	ws.requestCounter.Inc()
 
 	vars := mux.Vars(r)
-	location := vars["location"]
+	loc := weather.ParseLocation(vars["location"], r.URL.Query())
 
-	// Simulate weather data (in real implementation, fetch from external APIs)
-	weather := WeatherData{
-		Location:    location,
-		Temperature: 22.5 + float64(time.Now().Unix()%10),
-		Humidity:    65 + int(time.Now().Unix()%20),
-		Pressure:    1013.25,
-		WindSpeed:   5.2,
-		Timestamp:   time.Now(),
-		Source:      "CloudWeather-Sentinel-API",
+	if cached, ok := ws.cache.Get(loc.CacheKey()); ok {
+		writeJSON(w, cached)
+		ws.responseTime.Observe(time.Since(start).Seconds())
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(weather)
+	if !ws.limiter.Allow(weatherRouteLimiterKey) {
+		http.Error(w, "rate limit exceeded, try again shortly", http.StatusTooManyRequests)
+		return
+	}
+
+	result, err := ws.aggregator.Aggregate(r.Context(), loc)
+	if err != nil {
+		log.Printf("weather aggregation error: %v", err)
+		http.Error(w, "failed to fetch weather data", http.StatusBadGateway)
+		return
+	}
+
+	ws.cache.Set(loc.CacheKey(), result)
+	writeJSON(w, result)
+	ws.responseTime.Observe(time.Since(start).Seconds())
+}
+
+func (ws *WeatherService) GetForecast(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ws.requestCounter.Inc()
 
+	if ws.forecastProvider == nil {
+		http.Error(w, "no forecast provider configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+	loc := weather.ParseLocation(vars["location"], query)
+
+	opts := providers.ForecastOptions{
+		Hours: parseIntDefault(query.Get("hours"), 48),
+		Days:  parseIntDefault(query.Get("days"), 7),
+		Units: query.Get("units"),
+		Lang:  query.Get("lang"),
+	}
+
+	cacheKey := fmt.Sprintf("forecast:%s:%d:%d:%s:%s", loc.CacheKey(), opts.Hours, opts.Days, opts.Units, opts.Lang)
+	if cached, ok := ws.cache.Get(cacheKey); ok {
+		writeJSON(w, cached)
+		ws.responseTime.Observe(time.Since(start).Seconds())
+		return
+	}
+
+	if !ws.limiter.Allow(forecastRouteLimiterKey) {
+		http.Error(w, "rate limit exceeded, try again shortly", http.StatusTooManyRequests)
+		return
+	}
+
+	forecast, err := ws.forecastProvider.GetForecast(r.Context(), loc, opts)
+	if errors.Is(err, providers.ErrGeoRequired) {
+		http.Error(w, "forecast requires a lat/lon location: pass ?lat=&lon= or a \"lat,lon\" path value", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Printf("forecast provider error: %v", err)
+		http.Error(w, "failed to fetch forecast data", http.StatusBadGateway)
+		return
+	}
+
+	ws.cache.Set(cacheKey, forecast)
+	writeJSON(w, forecast)
 	ws.responseTime.Observe(time.Since(start).Seconds())
 }
 
+func parseIntDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
 func (ws *WeatherService) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	response := map[string]string{
 		"status":    "healthy",
@@ -76,29 +192,144 @@ func (ws *WeatherService) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// newProviders builds the set of active providers.Provider from the
+// PROVIDERS env var, a comma-separated list of provider names. It defaults
+// to OpenWeatherMap alone if unset.
+func newProviders() []providers.Provider {
+	names := strings.Split(os.Getenv("PROVIDERS"), ",")
+	if os.Getenv("PROVIDERS") == "" {
+		names = []string{"openweathermap"}
+	}
+
+	var provs []providers.Provider
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case "openweathermap":
+			cfg := providers.NewOpenWeatherMapConfigFromEnv(os.Getenv)
+			provs = append(provs, providers.NewOpenWeatherMapProvider(cfg, nil))
+		case "aviation":
+			cfg := providers.NewAviationConfigFromEnv(os.Getenv)
+			provs = append(provs, providers.NewAviationProvider(cfg, nil))
+		case "darksky":
+			cfg := providers.NewDarkSkyConfigFromEnv(os.Getenv)
+			provs = append(provs, providers.NewDarkSkyProvider(cfg, nil))
+		case "mock":
+			fixture := os.Getenv("MOCK_FIXTURE_PATH")
+			if fixture == "" {
+				fixture = "testdata/mock_weather.json"
+			}
+			p, err := providers.NewMockProvider("mock", fixture)
+			if err != nil {
+				log.Fatalf("failed to load mock provider: %v", err)
+			}
+			provs = append(provs, p)
+		}
+	}
+	return provs
+}
+
+// pickForecastProvider returns the first configured provider that also
+// implements ForecastProvider, or nil if none do.
+func pickForecastProvider(provs []providers.Provider) providers.ForecastProvider {
+	for _, p := range provs {
+		if fp, ok := p.(providers.ForecastProvider); ok {
+			return fp
+		}
+	}
+	return nil
+}
+
+// rateLimitByTier enforces per-subject quotas from tierLimiter against
+// the Claims the auth middleware stored in the request context. Requests
+// with no claims (auth disabled) pass through untouched - the per-route
+// GCRA limiter in WeatherService still applies to /weather and /forecast;
+// the aviation routes have no other rate limit.
+func rateLimitByTier(tierLimiter *ratelimit.TierLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := auth.ClaimsFromRequest(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !tierLimiter.Allow(claims.Subject, claims.Tier) {
+				http.Error(w, "rate limit exceeded for your subscription tier", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func main() {
-	ws := NewWeatherService()
-	
+	provs := newProviders()
+	agg := aggregator.New(provs, aggregator.DefaultProviderTimeout, aggregator.DefaultOutlierStdDevs)
+	ws := NewWeatherService(agg, pickForecastProvider(provs))
+
+	aviationSource := metar.NewSource(metar.NewConfigFromEnv(os.Getenv), nil)
+	as := NewAviationService(aviationSource)
+
+	influxClient, err := influx.NewClient(influx.NewConfigFromEnv(os.Getenv))
+	if err != nil {
+		log.Fatalf("failed to build influx client: %v", err)
+	}
+	hs := NewHistoryService(influxClient)
+
+	var coll *collector.Collector
+	if len(provs) > 0 {
+		coll = collector.New(provs[0], influxClient, collector.NewConfigFromEnv(os.Getenv))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go coll.Run(ctx)
+	}
+
+	authCfg := auth.NewConfigFromEnv(os.Getenv)
+	authMW := auth.NewMiddleware(authCfg)
+	var issuer *auth.Issuer
+	if authCfg.HMACSecret != nil {
+		issuer = auth.NewIssuer(authCfg.HMACSecret)
+	}
+	authService := NewAuthService(issuer, authCfg.LoginAPIKey)
+	tierLimiter := ratelimit.NewTierLimiter(
+		ratelimit.TierQuota{Tier: auth.TierFree, Rate: freeTierRatePerMin, Period: time.Minute, Burst: tierRateBurst},
+		ratelimit.TierQuota{Tier: auth.TierPremium, Rate: premiumTierRatePerMin, Period: time.Minute, Burst: tierRateBurst},
+	)
+
 	// Register Prometheus metrics
 	prometheus.MustRegister(ws.requestCounter)
 	prometheus.MustRegister(ws.responseTime)
+	prometheus.MustRegister(agg.Collectors()...)
+	prometheus.MustRegister(as.Collectors()...)
+	if coll != nil {
+		prometheus.MustRegister(coll.Collectors()...)
+	}
 
 	r := mux.NewRouter()
-	
-	// API routes
-	r.HandleFunc("/weather/{location}", ws.GetWeather).Methods("GET")
+
+	// Unauthenticated routes: health/metrics must always be reachable for
+	// liveness probes and scraping, and login/refresh are how a client
+	// gets a token in the first place.
 	r.HandleFunc("/health", ws.HealthCheck).Methods("GET")
 	r.Handle("/metrics", promhttp.Handler())
+	r.HandleFunc("/auth/login", authService.Login).Methods("POST")
+	r.HandleFunc("/auth/refresh", authService.Refresh).Methods("POST")
 
-	// CORS middleware
-	r.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			next.ServeHTTP(w, r)
-		})
-	})
+	// Weather routes require a valid bearer token whenever auth is
+	// enabled, and are rate-limited per-subject by the claim's tier.
+	protected := r.PathPrefix("/").Subrouter()
+	protected.Use(authMW.RequireAuth, rateLimitByTier(tierLimiter))
+	protected.HandleFunc("/weather/{location}", ws.GetWeather).Methods("GET")
+	protected.HandleFunc("/forecast/{location}", ws.GetForecast).Methods("GET")
+	protected.HandleFunc("/metar/{station}", as.GetMETAR).Methods("GET")
+	protected.HandleFunc("/taf/{station}", as.GetTAF).Methods("GET")
+	protected.HandleFunc("/history/{location}", hs.GetHistory).Methods("GET")
+
+	corsCfg := corsmw.NewConfigFromEnv(os.Getenv)
+	csrfHandler := nosurf.New(r)
+	csrfHandler.ExemptPath("/auth/login")
+	csrfHandler.ExemptPath("/auth/refresh")
+
+	chain := alice.New(corsmw.Middleware(corsCfg)).Then(csrfHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -106,5 +337,5 @@ func main() {
 	}
 
 	log.Printf("Weather API starting on port %s", port)
-	log.Fatal(http.ListenAndServe(":"+port, r))
-}
\ No newline at end of file
+	log.Fatal(http.ListenAndServe(":"+port, chain))
+}